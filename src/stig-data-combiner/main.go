@@ -7,16 +7,57 @@ import (
 	"os"
 	"path/filepath"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/stig-data-combiner/pkg/combiner"
+	"github.com/stig-data-combiner/pkg/gatekeeper"
+	"github.com/stig-data-combiner/pkg/output"
+	"github.com/stig-data-combiner/pkg/schema"
+	"github.com/stig-data-combiner/pkg/trust"
 )
 
+// defaultTrustPolicyPath is where the "trust" subcommand reads/writes the
+// policy.json-style trust document by default.
+const defaultTrustPolicyPath = "policy.json"
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "trust":
+			if err := runTrust(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "sign":
+			if err := runSign(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "verify":
+			if err := runVerify(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	// Command line flags
 	stigPath := flag.String("stig", "", "Path to STIG JSON file (optional, will auto-detect)")
 	winSTIGPath := flag.String("win-stig", "", "Path to win-stig repository (required)")
 	outputPath := flag.String("output", "benchmark-data.json", "Output JSON file path")
 	verbose := flag.Bool("verbose", false, "Enable verbose output")
 	pretty := flag.Bool("pretty", true, "Pretty-print JSON output")
+	gatekeeperDir := flag.String("gatekeeper-output", "", "Directory to write Gatekeeper ConstraintTemplates/Constraints (optional)")
+	enforcementMapPath := flag.String("enforcement-map", "", "Path to a JSON file overriding the severity/rule-id -> enforcementAction mapping")
+	trustPolicyPath := flag.String("trust-policy", "", "Path to a policy.json trust file gating which sources are combined (optional)")
+	formatsFlag := flag.String("format", "fleet", "Comma-separated whole-run documents to write into -output's directory (fleet, oscal, vex, trivy, or all); fleet is the benchmark-data.json the -output flag already names")
+	signKeyPath := flag.String("sign-key", "", "Base64-encoded ed25519 private key file; signs -output's directory with pkg/trust.SignBundle after it's written (see the sign subcommand to sign an existing directory without a combiner run)")
+	bundleTrustPolicyPath := flag.String("bundle-trust-policy", "", "Path to a pkg/trust.PolicyContent JSON file; verifies -output's directory with pkg/trust.VerifyBundle after it's written (see the verify subcommand to check an existing directory without a combiner run). Distinct from -trust-policy, which gates combiner input sources rather than this run's output")
+	gpoDir := flag.String("gpo-dir", "", "Directory of SYSVOL Group Policy Registry.pol exports (any filename; identified by content). A rule win-stig has no automation policy for is promoted to automatable when every one of its registry checks matches a value a GPO here actually deploys")
+	regoBundleDir := flag.String("rego-bundle", "", "Directory of operator-authored .rego-shaped decision files (see pkg/regobundle) evaluated against every rule ahead of the win-stig match and GPO promotion, overriding automatable/query/tags/severity")
 
 	flag.Parse()
 
@@ -56,6 +97,24 @@ func main() {
 
 	// Create combiner and process
 	c := combiner.NewCombiner(*stigPath, *winSTIGPath, *verbose)
+	if *trustPolicyPath != "" {
+		if err := c.SetTrustPolicy(*trustPolicyPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading trust policy: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *gpoDir != "" {
+		if err := c.SetGPODir(*gpoDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading GPO directory: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *regoBundleDir != "" {
+		if err := c.SetRegoBundle(*regoBundleDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading rego bundle: %v\n", err)
+			os.Exit(1)
+		}
+	}
 	data, err := c.Combine()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error combining data: %v\n", err)
@@ -80,6 +139,66 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Write any additional whole-run formats requested via -format
+	// alongside benchmark-data.json.
+	formats, err := output.ParseFormats(*formatsFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing -format: %v\n", err)
+		os.Exit(1)
+	}
+	outputDir := filepath.Dir(*outputPath)
+	if err := output.WriteFormats(data, outputDir, formats); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output formats: %v\n", err)
+		os.Exit(1)
+	}
+	if *verbose && len(formats) > 1 {
+		fmt.Printf("Wrote %v to %s\n", formats, outputDir)
+	}
+
+	if *signKeyPath != "" {
+		if err := trust.SignBundle(outputDir, *signKeyPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error signing output: %v\n", err)
+			os.Exit(1)
+		}
+		if *verbose {
+			fmt.Printf("Signed %s\n", outputDir)
+		}
+	}
+
+	if *bundleTrustPolicyPath != "" {
+		if err := trust.VerifyBundle(outputDir, *bundleTrustPolicyPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error verifying output: %v\n", err)
+			os.Exit(1)
+		}
+		if *verbose {
+			fmt.Printf("Verified %s against %s\n", outputDir, *bundleTrustPolicyPath)
+		}
+	}
+
+	// Export Gatekeeper ConstraintTemplates/Constraints if requested
+	if *gatekeeperDir != "" {
+		enforcementMap := gatekeeper.DefaultEnforcementMap
+		if *enforcementMapPath != "" {
+			overrides, err := loadEnforcementMap(*enforcementMapPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading enforcement map: %v\n", err)
+				os.Exit(1)
+			}
+			for k, v := range overrides {
+				enforcementMap[k] = v
+			}
+		}
+
+		if err := writeGatekeeperExport(data, *gatekeeperDir, enforcementMap); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing Gatekeeper export: %v\n", err)
+			os.Exit(1)
+		}
+
+		if *verbose {
+			fmt.Printf("Wrote Gatekeeper ConstraintTemplates/Constraints to: %s\n", *gatekeeperDir)
+		}
+	}
+
 	// Print summary
 	fmt.Printf("Generated benchmark data:\n")
 	fmt.Printf("  Framework: %s\n", data.Meta.Framework)
@@ -107,3 +226,199 @@ func main() {
 	fmt.Printf("  With fixes: %d\n", rulesWithFixes)
 	fmt.Printf("\nOutput written to: %s\n", *outputPath)
 }
+
+// runTrust implements the "stig-combiner trust show/set-default/set-repository"
+// subcommands, mirroring the shape of `podman trust`.
+func runTrust(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: stig-combiner trust show|set-default|set-repository [args...]")
+	}
+
+	switch args[0] {
+	case "show":
+		return runTrustShow(args[1:])
+	case "set-default":
+		return runTrustSetDefault(args[1:])
+	case "set-repository":
+		return runTrustSetRepository(args[1:])
+	default:
+		return fmt.Errorf("unknown trust subcommand %q", args[0])
+	}
+}
+
+func runTrustShow(args []string) error {
+	fs := flag.NewFlagSet("trust show", flag.ExitOnError)
+	policyPath := fs.String("policy", defaultTrustPolicyPath, "Path to the trust policy.json file")
+	fs.Parse(args)
+
+	policy, err := trust.LoadOrDefault(*policyPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(policy.Show())
+	return nil
+}
+
+func runTrustSetDefault(args []string) error {
+	fs := flag.NewFlagSet("trust set-default", flag.ExitOnError)
+	policyPath := fs.String("policy", defaultTrustPolicyPath, "Path to the trust policy.json file")
+	reqType := fs.String("type", "", "insecureAcceptAnything, signedBy, or reject (required)")
+	keyType := fs.String("key-type", trust.KeyTypeEd25519PublicKey, "Key type for signedBy requirements")
+	keyPath := fs.String("key-path", "", "Path to the key file for signedBy requirements")
+	fs.Parse(args)
+
+	policy, err := trust.LoadOrDefault(*policyPath)
+	if err != nil {
+		return err
+	}
+
+	req, err := newRequirement(*reqType, *keyType, *keyPath)
+	if err != nil {
+		return err
+	}
+
+	policy.SetDefault(req)
+	return trust.Save(*policyPath, policy)
+}
+
+func runTrustSetRepository(args []string) error {
+	fs := flag.NewFlagSet("trust set-repository", flag.ExitOnError)
+	policyPath := fs.String("policy", defaultTrustPolicyPath, "Path to the trust policy.json file")
+	reqType := fs.String("type", "", "insecureAcceptAnything, signedBy, or reject (required)")
+	keyType := fs.String("key-type", trust.KeyTypeEd25519PublicKey, "Key type for signedBy requirements")
+	keyPath := fs.String("key-path", "", "Path to the key file for signedBy requirements")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: stig-combiner trust set-repository <prefix> -type <type> [-key-type ed25519PublicKey -key-path <path>]")
+	}
+	prefix := fs.Arg(0)
+
+	policy, err := trust.LoadOrDefault(*policyPath)
+	if err != nil {
+		return err
+	}
+
+	req, err := newRequirement(*reqType, *keyType, *keyPath)
+	if err != nil {
+		return err
+	}
+
+	policy.SetSource(prefix, req)
+	return trust.Save(*policyPath, policy)
+}
+
+func newRequirement(reqType, keyType, keyPath string) (trust.Requirement, error) {
+	switch reqType {
+	case trust.TypeInsecureAcceptAnything, trust.TypeReject:
+		return trust.Requirement{Type: reqType}, nil
+	case trust.TypeSignedBy:
+		if keyPath == "" {
+			return trust.Requirement{}, fmt.Errorf("-key-path is required for a %s requirement", trust.TypeSignedBy)
+		}
+		return trust.Requirement{Type: reqType, KeyType: keyType, KeyPath: keyPath}, nil
+	default:
+		return trust.Requirement{}, fmt.Errorf("-type must be one of %s, %s, %s", trust.TypeInsecureAcceptAnything, trust.TypeSignedBy, trust.TypeReject)
+	}
+}
+
+// runSign signs an already-generated output directory in place, without
+// re-running the combiner (see the -sign-key flag for signing as part of
+// a combine).
+func runSign(args []string) error {
+	fs := flag.NewFlagSet("sign", flag.ExitOnError)
+	dir := fs.String("dir", "", "Output directory to sign (required)")
+	signKeyPath := fs.String("sign-key", "", "Base64-encoded ed25519 private key file (required)")
+	fs.Parse(args)
+
+	if *dir == "" || *signKeyPath == "" {
+		return fmt.Errorf("usage: stig-data-combiner sign -dir <output-dir> -sign-key <key-file>")
+	}
+
+	if err := trust.SignBundle(*dir, *signKeyPath); err != nil {
+		return err
+	}
+	fmt.Printf("Signed %s\n", *dir)
+	return nil
+}
+
+// runVerify checks an already-generated output directory's signature
+// against a bundle trust policy, without re-running the combiner.
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	dir := fs.String("dir", "", "Output directory to verify (required)")
+	bundleTrustPolicyPath := fs.String("trust-policy", "", "Path to a pkg/trust.PolicyContent JSON file (required)")
+	fs.Parse(args)
+
+	if *dir == "" || *bundleTrustPolicyPath == "" {
+		return fmt.Errorf("usage: stig-data-combiner verify -dir <output-dir> -trust-policy <policy.json>")
+	}
+
+	if err := trust.VerifyBundle(*dir, *bundleTrustPolicyPath); err != nil {
+		return err
+	}
+	fmt.Printf("%s satisfies %s\n", *dir, *bundleTrustPolicyPath)
+	return nil
+}
+
+// loadEnforcementMap reads a JSON object mapping severities or rule IDs to
+// a Gatekeeper enforcementAction, overriding gatekeeper.DefaultEnforcementMap.
+func loadEnforcementMap(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var overrides map[string]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return overrides, nil
+}
+
+// writeGatekeeperExport writes one ConstraintTemplate YAML per category and
+// one Constraint YAML per rule (for rules with a generated Rego module)
+// into outputDir.
+func writeGatekeeperExport(data *schema.BenchmarkData, outputDir string, enforcementMap map[string]string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", outputDir, err)
+	}
+
+	for _, category := range data.Categories {
+		template := gatekeeper.ExportCategory(category)
+		if len(template.Spec.Targets) == 0 {
+			continue
+		}
+
+		templateYAML, err := yaml.Marshal(template)
+		if err != nil {
+			return fmt.Errorf("marshaling ConstraintTemplate for %s: %w", category.ID, err)
+		}
+
+		templatePath := filepath.Join(outputDir, template.Metadata.Name+"-template.yaml")
+		if err := os.WriteFile(templatePath, templateYAML, 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", templatePath, err)
+		}
+
+		for _, rule := range category.Rules {
+			if rule.Rego == "" {
+				continue
+			}
+
+			constraint := gatekeeper.ExportConstraint(category, rule, enforcementMap)
+			constraintYAML, err := yaml.Marshal(constraint)
+			if err != nil {
+				return fmt.Errorf("marshaling Constraint for %s: %w", rule.RuleID, err)
+			}
+
+			constraintPath := filepath.Join(outputDir, constraint.Metadata.Name+"-constraint.yaml")
+			if err := os.WriteFile(constraintPath, constraintYAML, 0644); err != nil {
+				return fmt.Errorf("writing %s: %w", constraintPath, err)
+			}
+		}
+	}
+
+	return nil
+}
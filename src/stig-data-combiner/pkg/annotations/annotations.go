@@ -0,0 +1,115 @@
+// Package annotations parses OPA-style "# METADATA" front-matter blocks
+// embedded at the top of fix files (.ps1, .xml), so a fix author can carry
+// structured information — a human title/description, related links, and
+// custom.* overrides like severity_override, cci, or applies_to — alongside
+// the file without inventing a second sidecar format.
+package annotations
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// metadataMarker is the exact line (after trimming whitespace) that opens a
+// METADATA block, matching OPA's own annotation convention.
+const metadataMarker = "# METADATA"
+
+// Metadata is a parsed "# METADATA" block.
+type Metadata struct {
+	Title            string         `yaml:"title,omitempty"`
+	Description      string         `yaml:"description,omitempty"`
+	RelatedResources []string       `yaml:"related_resources,omitempty"`
+	Schemas          []string       `yaml:"schemas,omitempty"` // paths to JSON schema files
+	Custom           map[string]any `yaml:"custom,omitempty"`
+}
+
+// Parse extracts and decodes the "# METADATA" block at the top of a fix
+// file's content, if any. It returns (nil, nil) when the file has no such
+// block, and a non-nil error only when a block is present but isn't valid
+// YAML.
+func Parse(content string) (*Metadata, error) {
+	lines := strings.Split(content, "\n")
+
+	start := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if trimmed == metadataMarker {
+			start = i + 1
+		}
+		break
+	}
+	if start == -1 {
+		return nil, nil
+	}
+
+	var yamlLines []string
+	for _, line := range lines[start:] {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		yamlLines = append(yamlLines, strings.TrimPrefix(strings.TrimPrefix(trimmed, "#"), " "))
+	}
+	if len(yamlLines) == 0 {
+		return nil, nil
+	}
+
+	var meta Metadata
+	if err := yaml.Unmarshal([]byte(strings.Join(yamlLines, "\n")), &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse METADATA block: %w", err)
+	}
+
+	return &meta, nil
+}
+
+// AppliesToGlobs returns the custom.applies_to RuleID glob patterns, if any.
+func (m *Metadata) AppliesToGlobs() []string {
+	return stringSlice(m.Custom["applies_to"])
+}
+
+// SeverityOverride returns custom.severity_override, if set.
+func (m *Metadata) SeverityOverride() (string, bool) {
+	v, ok := m.Custom["severity_override"].(string)
+	return v, ok
+}
+
+// CustomFields returns the custom.* fields that aren't interpreted
+// specially by this package (applies_to, severity_override), for merging
+// verbatim into schema.Rule.Annotations.
+func (m *Metadata) CustomFields() map[string]any {
+	if len(m.Custom) == 0 {
+		return nil
+	}
+
+	out := make(map[string]any, len(m.Custom))
+	for k, v := range m.Custom {
+		if k == "applies_to" || k == "severity_override" {
+			continue
+		}
+		out[k] = v
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func stringSlice(v any) []string {
+	items, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package annotations
+
+import "testing"
+
+func TestParseExtractsMetadataBlock(t *testing.T) {
+	content := `# METADATA
+# title: Disable Telnet
+# description: Disables the Telnet service
+# custom:
+#   severity_override: high
+#   applies_to:
+#     - WN11-CC-000001
+#     - WN11-CC-000002
+#   owner: security-team
+$telnet = Get-Service -Name Telnet
+`
+
+	meta, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if meta == nil {
+		t.Fatal("expected a non-nil Metadata")
+	}
+	if meta.Title != "Disable Telnet" {
+		t.Errorf("got Title %q, want Disable Telnet", meta.Title)
+	}
+
+	if globs := meta.AppliesToGlobs(); len(globs) != 2 || globs[0] != "WN11-CC-000001" || globs[1] != "WN11-CC-000002" {
+		t.Errorf("got AppliesToGlobs() %v, want [WN11-CC-000001 WN11-CC-000002]", globs)
+	}
+
+	override, ok := meta.SeverityOverride()
+	if !ok || override != "high" {
+		t.Errorf("got SeverityOverride() = (%q, %v), want (high, true)", override, ok)
+	}
+
+	custom := meta.CustomFields()
+	if _, ok := custom["applies_to"]; ok {
+		t.Error("expected CustomFields to exclude applies_to")
+	}
+	if _, ok := custom["severity_override"]; ok {
+		t.Error("expected CustomFields to exclude severity_override")
+	}
+	if custom["owner"] != "security-team" {
+		t.Errorf("got custom[owner] %v, want security-team", custom["owner"])
+	}
+}
+
+func TestParseReturnsNilForFileWithNoMetadataBlock(t *testing.T) {
+	meta, err := Parse("$telnet = Get-Service -Name Telnet\n")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if meta != nil {
+		t.Errorf("got %+v, want nil metadata for a file with no METADATA block", meta)
+	}
+}
+
+func TestParseRejectsInvalidYAML(t *testing.T) {
+	content := "# METADATA\n# title: [unterminated\n"
+	if _, err := Parse(content); err == nil {
+		t.Error("expected Parse to reject an invalid YAML METADATA block")
+	}
+}
+
+func TestParseOnlyRecognizesMarkerAsFirstNonBlankLine(t *testing.T) {
+	content := "$x = 1\n# METADATA\n# title: too late\n"
+	meta, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if meta != nil {
+		t.Errorf("got %+v, want nil (METADATA marker must be the first non-blank line)", meta)
+	}
+}
+
+func TestCustomFieldsReturnsNilWhenNoCustomBlock(t *testing.T) {
+	meta := &Metadata{}
+	if got := meta.CustomFields(); got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
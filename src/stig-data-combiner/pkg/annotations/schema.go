@@ -0,0 +1,85 @@
+package annotations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+// RequirementSchema is the minimal JSON Schema subset this package
+// understands: an object schema (or an array-of-objects schema via Items)
+// declaring which RegistryCheck fields must be present and non-empty.
+type RequirementSchema struct {
+	Type     string             `json:"type"`
+	Items    *RequirementSchema `json:"items,omitempty"`
+	Required []string           `json:"required,omitempty"`
+}
+
+// LoadSchema reads and parses a JSON schema file referenced by a METADATA
+// block's `schemas` list.
+func LoadSchema(path string) (*RequirementSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema %s: %w", path, err)
+	}
+
+	var s RequirementSchema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse schema %s: %w", path, err)
+	}
+
+	return &s, nil
+}
+
+// ValidateRegistryChecks checks that every check in checks has the fields
+// sch (or sch.Items, for an array schema) declares as required.
+func ValidateRegistryChecks(sch *RequirementSchema, checks []schema.RegistryCheck) error {
+	target := sch
+	if sch.Type == "array" && sch.Items != nil {
+		target = sch.Items
+	}
+
+	for i, check := range checks {
+		fields := map[string]string{
+			"hive":          check.Hive,
+			"path":          check.Path,
+			"valueName":     check.ValueName,
+			"valueType":     check.ValueType,
+			"expectedValue": check.ExpectedValue,
+			"comparison":    check.Comparison,
+		}
+
+		for _, required := range target.Required {
+			if fields[required] == "" {
+				return fmt.Errorf("registry check %d is missing required field %q", i, required)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateSchemas resolves each schema path declared in m.Schemas relative
+// to baseDir and validates checks against it, returning the first failure.
+func (m *Metadata) ValidateSchemas(baseDir string, checks []schema.RegistryCheck) error {
+	for _, schemaPath := range m.Schemas {
+		resolved := schemaPath
+		if !filepath.IsAbs(resolved) {
+			resolved = filepath.Join(baseDir, schemaPath)
+		}
+
+		sch, err := LoadSchema(resolved)
+		if err != nil {
+			return err
+		}
+
+		if err := ValidateRegistryChecks(sch, checks); err != nil {
+			return fmt.Errorf("%s: %w", schemaPath, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package annotations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+func TestValidateRegistryChecksRequiresDeclaredFields(t *testing.T) {
+	sch := &RequirementSchema{Type: "object", Required: []string{"path", "expectedValue"}}
+
+	ok := []schema.RegistryCheck{{Path: `SOFTWARE\Policies`, ExpectedValue: "8"}}
+	if err := ValidateRegistryChecks(sch, ok); err != nil {
+		t.Errorf("ValidateRegistryChecks failed on a conforming check: %v", err)
+	}
+
+	missing := []schema.RegistryCheck{{Path: `SOFTWARE\Policies`}}
+	if err := ValidateRegistryChecks(sch, missing); err == nil {
+		t.Error("expected ValidateRegistryChecks to reject a check missing a required field")
+	}
+}
+
+func TestValidateRegistryChecksUnwrapsArraySchema(t *testing.T) {
+	sch := &RequirementSchema{
+		Type:  "array",
+		Items: &RequirementSchema{Type: "object", Required: []string{"valueName"}},
+	}
+
+	checks := []schema.RegistryCheck{{ValueName: "DriverLoadPolicy"}}
+	if err := ValidateRegistryChecks(sch, checks); err != nil {
+		t.Errorf("ValidateRegistryChecks failed on a conforming array-schema check: %v", err)
+	}
+
+	if err := ValidateRegistryChecks(sch, []schema.RegistryCheck{{}}); err == nil {
+		t.Error("expected ValidateRegistryChecks to reject a check missing the array schema's required field")
+	}
+}
+
+func TestLoadSchemaParsesJSONFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(path, []byte(`{"type":"object","required":["path"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sch, err := LoadSchema(path)
+	if err != nil {
+		t.Fatalf("LoadSchema failed: %v", err)
+	}
+	if sch.Type != "object" || len(sch.Required) != 1 || sch.Required[0] != "path" {
+		t.Errorf("got %+v", sch)
+	}
+}
+
+func TestValidateSchemasResolvesRelativeToBaseDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "schema.json"), []byte(`{"type":"object","required":["path"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := &Metadata{Schemas: []string{"schema.json"}}
+
+	if err := meta.ValidateSchemas(dir, []schema.RegistryCheck{{Path: "x"}}); err != nil {
+		t.Errorf("ValidateSchemas failed on a conforming check: %v", err)
+	}
+	if err := meta.ValidateSchemas(dir, []schema.RegistryCheck{{}}); err == nil {
+		t.Error("expected ValidateSchemas to reject a check missing a required field")
+	}
+}
+
+func TestValidateSchemasErrorsOnMissingSchemaFile(t *testing.T) {
+	meta := &Metadata{Schemas: []string{"does-not-exist.json"}}
+	if err := meta.ValidateSchemas(t.TempDir(), nil); err == nil {
+		t.Error("expected ValidateSchemas to error when a declared schema file is missing")
+	}
+}
@@ -1,9 +1,11 @@
 package combiner
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -11,7 +13,12 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/stig-data-combiner/pkg/annotations"
+	"github.com/stig-data-combiner/pkg/registrypol"
+	"github.com/stig-data-combiner/pkg/rego"
+	"github.com/stig-data-combiner/pkg/regobundle"
 	"github.com/stig-data-combiner/pkg/schema"
+	"github.com/stig-data-combiner/pkg/trust"
 )
 
 // STIGGroup represents a rule from the DISA STIG JSON
@@ -60,15 +67,129 @@ type Combiner struct {
 	stigPath    string // Path to STIG JSON file
 	winSTIGPath string // Path to win-stig repository
 	verbose     bool
+
+	trustPolicy *trust.Policy     // nil means no provenance verification
+	provenance  map[string]string // source key -> verified key fingerprint
+
+	// gpoIndex resolves a rule's check-content-parsed registry path/value
+	// name to the value a real SYSVOL GPO actually deploys there. Nil
+	// unless SetGPODir has been called, in which case convertToRules
+	// promotes a rule with matching registry checks to Automatable even
+	// when win-stig has no matching policy for it.
+	gpoIndex *registrypol.Index
+
+	// regoEngine evaluates each STIGGroup's fleet_policy/platform
+	// decisions (see pkg/regobundle) ahead of the win-stig match and GPO
+	// promotion above. Always set - NewCombiner installs regobundle's
+	// embedded no-op default bundle, which WithRegoEvaluator/
+	// SetRegoBundle replace with a real one.
+	regoEngine *regobundle.Engine
 }
 
-// NewCombiner creates a new Combiner instance
+// NewCombiner creates a new Combiner instance. regoEngine starts out as
+// regobundle's embedded default bundle (see pkg/regobundle.NewBundle),
+// which makes no decisions at all, so Combine's behavior is unchanged
+// until WithRegoEvaluator or SetRegoBundle installs a real one.
 func NewCombiner(stigPath, winSTIGPath string, verbose bool) *Combiner {
+	defaultEngine, _, err := regobundle.NewBundle("")
+	if err != nil {
+		// Only possible if pkg/regobundle's embedded default bundle itself
+		// fails to compile - a build-time programming error, not a
+		// runtime condition callers can recover from.
+		panic(fmt.Sprintf("regobundle: embedded default bundle failed to load: %v", err))
+	}
 	return &Combiner{
 		stigPath:    stigPath,
 		winSTIGPath: winSTIGPath,
 		verbose:     verbose,
+		regoEngine:  defaultEngine,
+	}
+}
+
+// SetTrustPolicy loads the policy.json-style trust file at path and enables
+// provenance verification: subsequent calls to Combine will refuse to
+// proceed if a required source signature is missing or invalid.
+func (c *Combiner) SetTrustPolicy(policyPath string) error {
+	policy, err := trust.Load(policyPath)
+	if err != nil {
+		return err
+	}
+	c.trustPolicy = policy
+	c.provenance = make(map[string]string)
+	return nil
+}
+
+// SetGPODir parses every SYSVOL Group Policy Registry.pol export under
+// dir (see pkg/registrypol.ParseDir - any filename, identified by
+// content) and enables GPO-backed promotion: subsequent calls to Combine
+// will mark a rule Automatable when its check-content-parsed registry
+// checks all match a value a real GPO in dir actually deploys, even if
+// win-stig has no automation policy for that rule.
+func (c *Combiner) SetGPODir(dir string) error {
+	checks, err := registrypol.ParseDir(dir)
+	if err != nil {
+		return err
+	}
+	c.gpoIndex = registrypol.NewIndex(checks)
+	return nil
+}
+
+// WithRegoEvaluator installs engine as c's rego-bundle decision source,
+// replacing the embedded default NewCombiner started with. Subsequent
+// calls to Combine evaluate each STIGGroup's fleet_policy decision
+// (automatable/query/tags/severity) and platform decision against engine
+// ahead of the win-stig match and GPO promotion, so a bundle policy can
+// promote a rule to Automatable with its own query, or override its
+// severity/tags/platform, without recompiling stig-data-combiner.
+// Exposed separately from SetRegoBundle so a caller that already has a
+// compiled *regobundle.Engine (e.g. a test, or one bundle shared across
+// several Combiner instances) can install it directly without writing it
+// to disk first.
+func (c *Combiner) WithRegoEvaluator(engine *regobundle.Engine) {
+	c.regoEngine = engine
+}
+
+// SetRegoBundle compiles every .rego file under dir (see
+// pkg/regobundle.NewBundle) and installs it via WithRegoEvaluator. A file
+// that fails to compile is skipped (logged when verbose) rather than
+// failing the whole bundle; only a dir that can't be read at all is a
+// hard error.
+func (c *Combiner) SetRegoBundle(dir string) error {
+	engine, compileErrors, err := regobundle.NewBundle(dir)
+	if err != nil {
+		return err
+	}
+	if c.verbose {
+		for _, ce := range compileErrors {
+			fmt.Printf("Warning: skipping rego bundle file: %v\n", ce)
+		}
 	}
+	c.WithRegoEvaluator(engine)
+	return nil
+}
+
+// verifySource checks sourcePath against the trust policy under sourceKey
+// (e.g. "disa:benchmark.json") and records the verified fingerprint. It is
+// a no-op when no trust policy has been configured.
+func (c *Combiner) verifySource(sourceKey, sourcePath string) error {
+	if c.trustPolicy == nil {
+		return nil
+	}
+
+	reqs := c.trustPolicy.RequirementsFor(sourceKey)
+	fingerprint, err := trust.Verify(sourcePath, reqs)
+	if err != nil {
+		return fmt.Errorf("trust verification failed for %s: %w", sourceKey, err)
+	}
+
+	if fingerprint != "" {
+		c.provenance[sourceKey] = fingerprint
+		if c.verbose {
+			fmt.Printf("Verified %s (fingerprint %s)\n", sourceKey, fingerprint)
+		}
+	}
+
+	return nil
 }
 
 // Combine reads all sources and produces unified BenchmarkData
@@ -94,7 +215,7 @@ func (c *Combiner) Combine() (*schema.BenchmarkData, error) {
 	}
 
 	// Read fix files
-	fixes, err := c.readFixFiles()
+	fixes, fixMeta, err := c.readFixFiles()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read fix files: %w", err)
 	}
@@ -107,7 +228,10 @@ func (c *Combiner) Combine() (*schema.BenchmarkData, error) {
 	policyByTitle := c.buildPolicyIndex(policies)
 
 	// Convert STIG groups to rules, enriching with win-stig data
-	rules := c.convertToRules(stigData.Groups, policyByTitle, fixes)
+	rules, err := c.convertToRules(stigData.Groups, policyByTitle, fixes, fixMeta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert rules: %w", err)
+	}
 
 	// Categorize rules
 	categories := c.categorizeRules(rules)
@@ -119,6 +243,7 @@ func (c *Combiner) Combine() (*schema.BenchmarkData, error) {
 			Title:       stigData.Title,
 			Version:     stigData.Version,
 			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			Provenance:  c.provenance,
 		},
 		Categories: categories,
 	}
@@ -158,6 +283,10 @@ func (c *Combiner) readSTIGJSON() (*STIGData, error) {
 		fmt.Printf("Using STIG JSON from: %s\n", foundPath)
 	}
 
+	if err := c.verifySource("disa:"+filepath.Base(foundPath), foundPath); err != nil {
+		return nil, err
+	}
+
 	var stigData STIGData
 	if err := json.Unmarshal(data, &stigData); err != nil {
 		return nil, fmt.Errorf("failed to parse STIG JSON: %w", err)
@@ -174,6 +303,10 @@ func (c *Combiner) readWinSTIGPolicies() ([]WinSTIGPolicy, error) {
 		return nil, fmt.Errorf("failed to read %s: %w", path, err)
 	}
 
+	if err := c.verifySource("winstig:"+filepath.Base(path), path); err != nil {
+		return nil, err
+	}
+
 	// The file contains multiple YAML documents separated by ---
 	var policies []WinSTIGPolicy
 	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
@@ -196,14 +329,17 @@ func (c *Combiner) readWinSTIGPolicies() ([]WinSTIGPolicy, error) {
 	return policies, nil
 }
 
-// readFixFiles reads all fix files from win-stig/fix/
-func (c *Combiner) readFixFiles() (map[string]*schema.Fix, error) {
+// readFixFiles reads all fix files from win-stig/fix/, along with any
+// "# METADATA" annotation block each .ps1/.xml fix declares (see
+// pkg/annotations).
+func (c *Combiner) readFixFiles() (map[string]*schema.Fix, map[string]*annotations.Metadata, error) {
 	fixDir := filepath.Join(c.winSTIGPath, "fix")
 	fixes := make(map[string]*schema.Fix)
+	fixMeta := make(map[string]*annotations.Metadata)
 
 	entries, err := os.ReadDir(fixDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read fix directory: %w", err)
+		return nil, nil, fmt.Errorf("failed to read fix directory: %w", err)
 	}
 
 	for _, entry := range entries {
@@ -220,11 +356,14 @@ func (c *Combiner) readFixFiles() (map[string]*schema.Fix, error) {
 			fixType = schema.FixTypeXML
 		case ".ps1":
 			fixType = schema.FixTypePowerShell
+		case ".pol":
+			fixType = schema.FixTypeRegistryPol
 		default:
 			continue // Skip unknown file types
 		}
 
-		content, err := os.ReadFile(filepath.Join(fixDir, filename))
+		fixPath := filepath.Join(fixDir, filename)
+		raw, err := os.ReadFile(fixPath)
 		if err != nil {
 			if c.verbose {
 				fmt.Printf("Warning: failed to read fix file %s: %v\n", filename, err)
@@ -232,14 +371,41 @@ func (c *Combiner) readFixFiles() (map[string]*schema.Fix, error) {
 			continue
 		}
 
+		if err := c.verifySource("fix:"+filename, fixPath); err != nil {
+			return nil, nil, err
+		}
+
+		content := string(raw)
+		switch fixType {
+		case schema.FixTypeRegistryPol:
+			// Registry.pol is binary; round-trip through the registrypol
+			// decoder first so a malformed file is caught here rather than
+			// silently shipped, then store base64 like any other fix content.
+			if _, err := registrypol.Read(raw); err != nil {
+				if c.verbose {
+					fmt.Printf("Warning: invalid Registry.pol file %s: %v\n", filename, err)
+				}
+				continue
+			}
+			content = base64.StdEncoding.EncodeToString(raw)
+		case schema.FixTypeXML, schema.FixTypePowerShell:
+			if meta, err := annotations.Parse(content); err != nil {
+				if c.verbose {
+					fmt.Printf("Warning: invalid METADATA block in %s: %v\n", filename, err)
+				}
+			} else if meta != nil {
+				fixMeta[filename] = meta
+			}
+		}
+
 		fixes[filename] = &schema.Fix{
 			Filename: filename,
 			Type:     fixType,
-			Content:  string(content),
+			Content:  content,
 		}
 	}
 
-	return fixes, nil
+	return fixes, fixMeta, nil
 }
 
 // normalizeTitle normalizes a title for matching
@@ -272,13 +438,29 @@ func (c *Combiner) buildPolicyIndex(policies []WinSTIGPolicy) map[string]*WinSTI
 	return index
 }
 
+// fixGlob pairs a fix file's custom.applies_to RuleID glob pattern with the
+// filename it came from, so a single fix can legitimately cover multiple
+// rules instead of only the one name-linked via policy.Spec.Fix.
+type fixGlob struct {
+	filename string
+	pattern  string
+}
+
 // convertToRules converts STIG groups to unified Rule format, enriched with win-stig data
-func (c *Combiner) convertToRules(groups []STIGGroup, policyByTitle map[string]*WinSTIGPolicy, fixes map[string]*schema.Fix) []schema.Rule {
+func (c *Combiner) convertToRules(groups []STIGGroup, policyByTitle map[string]*WinSTIGPolicy, fixes map[string]*schema.Fix, fixMeta map[string]*annotations.Metadata) ([]schema.Rule, error) {
 	var rules []schema.Rule
 
 	matched := 0
 	unmatched := 0
 
+	var globs []fixGlob
+	for filename, meta := range fixMeta {
+		for _, pattern := range meta.AppliesToGlobs() {
+			globs = append(globs, fixGlob{filename: filename, pattern: pattern})
+		}
+	}
+	fixDir := filepath.Join(c.winSTIGPath, "fix")
+
 	for _, group := range groups {
 		// Normalize title for matching
 		normalizedTitle := normalizeTitle(group.RuleTitle)
@@ -308,26 +490,131 @@ func (c *Combiner) convertToRules(groups []STIGGroup, policyByTitle map[string]*
 			unmatched++
 		}
 
+		// Fall back to a fix file whose custom.applies_to glob matches this
+		// rule's ID, when no fix was linked by name above.
+		if fix == nil {
+			for _, g := range globs {
+				if ok, err := path.Match(g.pattern, group.RuleVersion); err == nil && ok {
+					if f, exists := fixes[g.filename]; exists {
+						fix = f
+						break
+					}
+				}
+			}
+		}
+
+		// Evaluate the rego bundle (c.regoEngine - the embedded no-op
+		// default unless SetRegoBundle/WithRegoEvaluator installed a real
+		// one) ahead of GPO promotion below: a matching fleet_policy
+		// decision can promote a rule to Automatable with its own query
+		// even when win-stig already marked it manual-only, and always
+		// contributes its tags/severity/platform further down.
+		var regoDecision regobundle.RuleDecision
+		if c.regoEngine != nil {
+			regoDecision = c.regoEngine.Evaluate(regobundle.Group{
+				GroupID:            group.GroupID,
+				RuleID:             group.RuleID,
+				RuleVersion:        group.RuleVersion,
+				RuleTitle:          group.RuleTitle,
+				RuleSeverity:       group.RuleSeverity,
+				RuleVulnDiscussion: group.RuleVulnDiscussion,
+				RuleCheckContent:   group.RuleCheckContent,
+				RuleFixText:        group.RuleFixText,
+				RuleIdent:          group.RuleIdent,
+			})
+			if regoDecision.Automatable {
+				automatable = true
+				if regoDecision.Query != "" {
+					query = regoDecision.Query
+				}
+			}
+		}
+
 		// Parse registry checks from check content
 		registryChecks := parseRegistryChecks(group.RuleCheckContent)
 
+		// When win-stig had no automation for this rule, fall back to
+		// promoting it using a real GPO-deployed value for every one of
+		// its parsed registry checks (see SetGPODir). A rule with no
+		// registry checks, or with a check a configured GPO doesn't
+		// cover, is left as manual review same as before -gpo-dir
+		// existed.
+		if !automatable && c.gpoIndex != nil && len(registryChecks) > 0 {
+			if resolved, ok := c.resolveFromGPO(registryChecks); ok {
+				registryChecks = resolved
+				automatable = true
+				query = generateOsquerySQL(registryChecks)
+			}
+		}
+
+		// Merge the METADATA annotations of whichever fix ended up
+		// attached, if any: a declared severity_override wins, schemas are
+		// validated against the parsed registry checks, and any remaining
+		// custom.* fields are copied into Rule.Annotations verbatim.
+		severity := group.RuleSeverity
+		var ruleAnnotations map[string]any
+		if fix != nil {
+			if meta, ok := fixMeta[fix.Filename]; ok {
+				if len(meta.Schemas) > 0 {
+					if err := meta.ValidateSchemas(fixDir, registryChecks); err != nil {
+						return nil, fmt.Errorf("fix %s does not match declared schema for rule %s: %w", fix.Filename, group.RuleVersion, err)
+					}
+				}
+				if override, ok := meta.SeverityOverride(); ok {
+					severity = override
+				}
+				ruleAnnotations = meta.CustomFields()
+			}
+		}
+		if regoDecision.Severity != "" {
+			severity = regoDecision.Severity
+		}
+
 		// Build title with STIG ID prefix
 		title := fmt.Sprintf("%s - %s", group.RuleVersion, group.RuleTitle)
 
+		// Synthesize an OPA Rego equivalent of the osquery check so rules
+		// can be evaluated without osquery (e.g. via Conftest/Gatekeeper).
+		regoModule := ""
+		if len(registryChecks) > 0 {
+			regoModule = rego.GeneratePolicy(group.RuleVersion, group.RuleSeverity, group.RuleIdent, registryChecks)
+		}
+
+		// When no fix file was matched but we have parsed registry checks,
+		// synthesize a native Registry.pol so operators can drop it
+		// directly into SYSVOL without hand-translating the XML/PS1 fixes.
+		if fix == nil && len(registryChecks) > 0 {
+			if polBytes, err := registrypol.Write(registryChecks); err == nil {
+				fix = &schema.Fix{
+					Filename: fmt.Sprintf("%s.pol", group.RuleVersion),
+					Type:     schema.FixTypeRegistryPol,
+					Content:  base64.StdEncoding.EncodeToString(polBytes),
+				}
+			} else if c.verbose {
+				fmt.Printf("Warning: failed to synthesize Registry.pol for %s: %v\n", group.RuleVersion, err)
+			}
+		}
+
+		tags := []string{"STIG", "Windows11", group.RuleSeverity}
+		tags = append(tags, regoDecision.Tags...)
+
 		rule := schema.Rule{
 			ID:             group.GroupID,
 			RuleID:         group.RuleVersion,
 			Title:          title,
-			Severity:       group.RuleSeverity,
+			Severity:       severity,
 			Description:    group.RuleVulnDiscussion,
 			CheckContent:   group.RuleCheckContent,
 			FixText:        group.RuleFixText,
 			Automatable:    automatable,
 			Query:          query,
+			Rego:           regoModule,
 			RegistryChecks: registryChecks,
 			Fix:            fix,
 			CCI:            group.RuleIdent,
-			Tags:           []string{"STIG", "Windows11", group.RuleSeverity},
+			Tags:           tags,
+			Platform:       regoDecision.Platform,
+			Annotations:    ruleAnnotations,
 		}
 
 		rules = append(rules, rule)
@@ -337,7 +624,28 @@ func (c *Combiner) convertToRules(groups []STIGGroup, policyByTitle map[string]*
 		fmt.Printf("Matched %d rules with win-stig policies, %d unmatched\n", matched, unmatched)
 	}
 
-	return rules
+	return rules, nil
+}
+
+// resolveFromGPO looks up every one of checks against c.gpoIndex by
+// Path/ValueName. It only returns ok=true when ALL of them resolve - a
+// partially-confirmed rule still needs the manual steps a STIG reviewer
+// would perform for whichever check isn't actually deployed. On success,
+// the returned checks have ExpectedValue filled in from the real GPO
+// value wherever the check-content parse didn't already find one.
+func (c *Combiner) resolveFromGPO(checks []schema.RegistryCheck) ([]schema.RegistryCheck, bool) {
+	resolved := make([]schema.RegistryCheck, len(checks))
+	for i, check := range checks {
+		deployed, ok := c.gpoIndex.Lookup(check.Path, check.ValueName)
+		if !ok {
+			return nil, false
+		}
+		if check.ExpectedValue == "" {
+			check.ExpectedValue = deployed.ExpectedValue
+		}
+		resolved[i] = check
+	}
+	return resolved, true
 }
 
 // categorizeRules groups rules into categories based on DISA STIG rule ID prefix
@@ -0,0 +1,59 @@
+package combiner
+
+import (
+	"testing"
+
+	"github.com/stig-data-combiner/pkg/registrypol"
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+func TestResolveFromGPOFillsMissingExpectedValue(t *testing.T) {
+	c := &Combiner{gpoIndex: registrypol.NewIndex([]schema.RegistryCheck{
+		{Path: `SOFTWARE\Policies\Microsoft\Windows\EarlyLaunchAntiMalware`, ValueName: "DriverLoadPolicy", ExpectedValue: "8"},
+	})}
+
+	checks := []schema.RegistryCheck{
+		{Path: `SOFTWARE\Policies\Microsoft\Windows\EarlyLaunchAntiMalware`, ValueName: "DriverLoadPolicy", ValueType: "REG_DWORD", Comparison: "equals"},
+	}
+
+	resolved, ok := c.resolveFromGPO(checks)
+	if !ok {
+		t.Fatal("expected all checks to resolve")
+	}
+	if resolved[0].ExpectedValue != "8" {
+		t.Errorf("got ExpectedValue %q, want 8", resolved[0].ExpectedValue)
+	}
+}
+
+func TestResolveFromGPORequiresEveryCheckToMatch(t *testing.T) {
+	c := &Combiner{gpoIndex: registrypol.NewIndex([]schema.RegistryCheck{
+		{Path: `SOFTWARE\Policies\Microsoft\Windows\EarlyLaunchAntiMalware`, ValueName: "DriverLoadPolicy", ExpectedValue: "8"},
+	})}
+
+	checks := []schema.RegistryCheck{
+		{Path: `SOFTWARE\Policies\Microsoft\Windows\EarlyLaunchAntiMalware`, ValueName: "DriverLoadPolicy"},
+		{Path: `SOFTWARE\Policies\Microsoft\Windows\SomethingElse`, ValueName: "NotDeployed"},
+	}
+
+	if _, ok := c.resolveFromGPO(checks); ok {
+		t.Fatal("expected resolveFromGPO to fail when one check has no matching GPO entry")
+	}
+}
+
+func TestGenerateOsquerySQLEqualsAndNotExists(t *testing.T) {
+	sql := generateOsquerySQL([]schema.RegistryCheck{
+		{Hive: "HKEY_LOCAL_MACHINE", Path: `SOFTWARE\Policies`, ValueName: "DriverLoadPolicy", Comparison: "equals", ExpectedValue: "8"},
+	})
+	want := `SELECT 1 FROM registry WHERE (path = 'HKEY_LOCAL_MACHINE\SOFTWARE\Policies\DriverLoadPolicy' AND data = '8');`
+	if sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+
+	sql = generateOsquerySQL([]schema.RegistryCheck{
+		{Hive: "HKEY_LOCAL_MACHINE", Path: `SOFTWARE\Policies`, ValueName: "Disabled", Comparison: "not_exists"},
+	})
+	want = `SELECT 1 WHERE NOT EXISTS (SELECT 1 FROM registry WHERE path = 'HKEY_LOCAL_MACHINE\SOFTWARE\Policies\Disabled');`
+	if sql != want {
+		t.Errorf("got %q, want %q", sql, want)
+	}
+}
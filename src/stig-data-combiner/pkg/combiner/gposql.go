@@ -0,0 +1,56 @@
+package combiner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+// generateOsquerySQL renders checks as a single osquery query against the
+// registry table, ANDing every check together. This is a much narrower
+// subset than stig-processor's own pkg/parser.GenerateOsquerySQL (no
+// REG_MULTI_SZ-specific handling). Its caller is the GPO promotion in
+// convertToRules (see resolveFromGPO): equals checks compare data to
+// ExpectedValue as a string, greater_equal/less_equal checks compare it
+// as an integer, and a check with no ExpectedValue only requires the
+// value to be present.
+func generateOsquerySQL(checks []schema.RegistryCheck) string {
+	if len(checks) == 0 {
+		return ""
+	}
+
+	var conditions []string
+	for _, check := range checks {
+		fullPath := fmt.Sprintf("%s\\%s\\%s", check.Hive, check.Path, check.ValueName)
+		switch check.Comparison {
+		case "not_exists":
+			conditions = append(conditions, fmt.Sprintf("NOT EXISTS (SELECT 1 FROM registry WHERE path = '%s')", fullPath))
+		case "must_exist":
+			conditions = append(conditions, fmt.Sprintf("path = '%s'", fullPath))
+		case "":
+			conditions = append(conditions, fmt.Sprintf("(path = '%s' AND data IS NOT NULL)", fullPath))
+		case "greater_equal", "less_equal":
+			op := ">="
+			if check.Comparison == "less_equal" {
+				op = "<="
+			}
+			if check.ExpectedValue == "" {
+				conditions = append(conditions, fmt.Sprintf("(path = '%s' AND data IS NOT NULL)", fullPath))
+			} else {
+				conditions = append(conditions, fmt.Sprintf("(path = '%s' AND CAST(data AS INTEGER) %s %s)", fullPath, op, check.ExpectedValue))
+			}
+		default:
+			if check.ExpectedValue == "" {
+				conditions = append(conditions, fmt.Sprintf("(path = '%s' AND data IS NOT NULL)", fullPath))
+			} else {
+				conditions = append(conditions, fmt.Sprintf("(path = '%s' AND data = '%s')", fullPath, check.ExpectedValue))
+			}
+		}
+	}
+
+	if len(conditions) == 1 && strings.Contains(conditions[0], "NOT EXISTS") {
+		return fmt.Sprintf("SELECT 1 WHERE %s;", conditions[0])
+	}
+	return fmt.Sprintf("SELECT 1 FROM registry WHERE %s;", strings.Join(conditions, " AND "))
+}
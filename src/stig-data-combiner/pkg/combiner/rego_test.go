@@ -0,0 +1,97 @@
+package combiner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stig-data-combiner/pkg/regobundle"
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewCombinerInstallsEmbeddedDefaultRegoEngine(t *testing.T) {
+	c := NewCombiner("stig.json", "winstig", false)
+	if c.regoEngine == nil {
+		t.Fatal("expected NewCombiner to install the embedded default rego engine")
+	}
+
+	rules, err := c.convertToRules([]STIGGroup{
+		{GroupID: "V-1", RuleVersion: "WN11-CC-000001", RuleTitle: "anything", RuleSeverity: "medium"},
+	}, map[string]*WinSTIGPolicy{}, map[string]*schema.Fix{}, nil)
+	if err != nil {
+		t.Fatalf("convertToRules failed: %v", err)
+	}
+	if len(rules) != 1 {
+		t.Fatalf("got %d rules, want 1", len(rules))
+	}
+	if rules[0].Automatable || rules[0].Platform != "" {
+		t.Errorf("expected the embedded default bundle to contribute nothing, got %+v", rules[0])
+	}
+}
+
+func TestWithRegoEvaluatorPromotesAndSetsPlatform(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "linux.rego", `package stig.platform
+import input.group.RuleVersion
+default automatable = false
+automatable {
+	v := input.group.RuleVersion
+	startswith(v, "WN11-CC")
+}
+query := "SELECT 1 FROM registry WHERE path = 'x';"
+tags := ["from-bundle"]
+severity := "critical"
+platform := "linux"
+`)
+
+	engine, compileErrors, err := regobundle.NewBundle(dir)
+	if err != nil {
+		t.Fatalf("NewBundle failed: %v", err)
+	}
+	if len(compileErrors) != 0 {
+		t.Fatalf("unexpected compile errors: %v", compileErrors)
+	}
+
+	c := NewCombiner("stig.json", "winstig", false)
+	c.WithRegoEvaluator(engine)
+
+	rules, err := c.convertToRules([]STIGGroup{
+		{GroupID: "V-1", RuleVersion: "WN11-CC-000001", RuleTitle: "anything", RuleSeverity: "medium"},
+	}, map[string]*WinSTIGPolicy{}, nil, nil)
+	if err != nil {
+		t.Fatalf("convertToRules failed: %v", err)
+	}
+	if len(rules) != 1 {
+		t.Fatalf("got %d rules, want 1", len(rules))
+	}
+
+	rule := rules[0]
+	if !rule.Automatable {
+		t.Error("expected rule to be promoted to automatable by the rego bundle")
+	}
+	if rule.Query != "SELECT 1 FROM registry WHERE path = 'x';" {
+		t.Errorf("got query %q, want the bundle's query", rule.Query)
+	}
+	if rule.Severity != "critical" {
+		t.Errorf("got severity %q, want critical", rule.Severity)
+	}
+	if rule.Platform != "linux" {
+		t.Errorf("got platform %q, want linux", rule.Platform)
+	}
+	found := false
+	for _, tag := range rule.Tags {
+		if tag == "from-bundle" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("got tags %v, want from-bundle included", rule.Tags)
+	}
+}
@@ -0,0 +1,169 @@
+// Package gatekeeper exports benchmark data as OPA Gatekeeper
+// ConstraintTemplate/Constraint pairs, one template per STIG category and
+// one constraint per rule, so the same STIG corpus that drives osquery
+// policies can also gate admission and periodic audit in a Kubernetes
+// cluster.
+package gatekeeper
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+// ConstraintTemplate is the subset of the Gatekeeper ConstraintTemplate CRD
+// this exporter populates.
+type ConstraintTemplate struct {
+	APIVersion string                 `yaml:"apiVersion"`
+	Kind       string                 `yaml:"kind"`
+	Metadata   Metadata               `yaml:"metadata"`
+	Spec       ConstraintTemplateSpec `yaml:"spec"`
+}
+
+type ConstraintTemplateSpec struct {
+	CRD     CRD      `yaml:"crd"`
+	Targets []Target `yaml:"targets"`
+}
+
+type CRD struct {
+	Spec CRDSpec `yaml:"spec"`
+}
+
+type CRDSpec struct {
+	Names CRDNames `yaml:"names"`
+}
+
+type CRDNames struct {
+	Kind string `yaml:"kind"`
+}
+
+type Target struct {
+	Target string `yaml:"target"`
+	Rego   string `yaml:"rego"`
+}
+
+// Constraint is the subset of a Gatekeeper Constraint instance this exporter
+// populates, including the newer scoped-enforcement-actions model.
+type Constraint struct {
+	APIVersion string         `yaml:"apiVersion"`
+	Kind       string         `yaml:"kind"`
+	Metadata   Metadata       `yaml:"metadata"`
+	Spec       ConstraintSpec `yaml:"spec"`
+}
+
+type Metadata struct {
+	Name        string            `yaml:"name"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+type ConstraintSpec struct {
+	EnforcementAction        string             `yaml:"enforcementAction,omitempty"`
+	ScopedEnforcementActions []ScopedEnforcement `yaml:"scopedEnforcementActions,omitempty"`
+}
+
+// ScopedEnforcement lets operators set a different action per enforcement
+// point (e.g. only warn on the admission webhook but deny during audit).
+type ScopedEnforcement struct {
+	Action            string   `yaml:"action"`
+	EnforcementPoints []string `yaml:"enforcementPoints"`
+}
+
+// DefaultEnforcementMap is the severity-to-action mapping used when the
+// caller doesn't override it via -enforcement-map.
+var DefaultEnforcementMap = map[string]string{
+	schema.SeverityHigh:   "deny",
+	schema.SeverityMedium: "warn",
+	schema.SeverityLow:    "dryrun",
+}
+
+// ExportCategory builds the ConstraintTemplate for one category: one Rego
+// target per rule that has a generated Rego module, embedded directly so
+// Gatekeeper doesn't need a separate bundle fetch.
+func ExportCategory(category schema.Category) ConstraintTemplate {
+	kind := "Stig" + toPascalCase(category.ID)
+
+	var targets []Target
+	for _, rule := range category.Rules {
+		if rule.Rego == "" {
+			continue
+		}
+		targets = append(targets, Target{
+			Target: "admission.k8s.gatekeeper.sh",
+			Rego:   rule.Rego,
+		})
+	}
+
+	return ConstraintTemplate{
+		APIVersion: "templates.gatekeeper.sh/v1",
+		Kind:       "ConstraintTemplate",
+		Metadata:   Metadata{Name: strings.ToLower(kind)},
+		Spec: ConstraintTemplateSpec{
+			CRD:     CRD{Spec: CRDSpec{Names: CRDNames{Kind: kind}}},
+			Targets: targets,
+		},
+	}
+}
+
+// ExportConstraint builds the Constraint instance for a single rule. Its
+// spec.enforcementAction is looked up in enforcementMap by rule ID first,
+// then by lower-cased severity, then in DefaultEnforcementMap by severity,
+// and is "warn" when none of those match. The scoped model gets one audit
+// and one webhook entry, both carrying that same action.
+func ExportConstraint(category schema.Category, rule schema.Rule, enforcementMap map[string]string) Constraint {
+	kind := "Stig" + toPascalCase(category.ID)
+
+	action, ok := enforcementMap[rule.RuleID]
+	if !ok {
+		action, ok = enforcementMap[strings.ToLower(rule.Severity)]
+	}
+	if !ok {
+		action = DefaultEnforcementMap[strings.ToLower(rule.Severity)]
+	}
+	if action == "" {
+		action = "warn"
+	}
+
+	return Constraint{
+		APIVersion: "constraints.gatekeeper.sh/v1beta1",
+		Kind:       kind,
+		Metadata: Metadata{
+			Name: sanitizeName(rule.RuleID),
+			Annotations: map[string]string{
+				"stig.cci":     rule.CCI,
+				"stig.rule_id": rule.RuleID,
+			},
+		},
+		Spec: ConstraintSpec{
+			EnforcementAction: action,
+			ScopedEnforcementActions: []ScopedEnforcement{
+				{Action: action, EnforcementPoints: []string{"audit"}},
+				{Action: action, EnforcementPoints: []string{"webhook"}},
+			},
+		},
+	}
+}
+
+var nonAlnum = regexp.MustCompile(`[^a-z0-9]+`)
+
+func sanitizeName(name string) string {
+	name = nonAlnum.ReplaceAllString(strings.ToLower(name), "-")
+	name = strings.Trim(name, "-")
+	if name == "" {
+		name = "stig-rule"
+	}
+	return name
+}
+
+func toPascalCase(id string) string {
+	parts := strings.FieldsFunc(id, func(r rune) bool { return r == '-' || r == '_' })
+	var b strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "%s%s", strings.ToUpper(p[:1]), strings.ToLower(p[1:]))
+	}
+	return b.String()
+}
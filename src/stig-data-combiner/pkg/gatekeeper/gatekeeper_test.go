@@ -0,0 +1,98 @@
+package gatekeeper
+
+import (
+	"testing"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+func TestExportCategoryIncludesOnlyRulesWithRego(t *testing.T) {
+	category := schema.Category{
+		ID: "wn11-cc",
+		Rules: []schema.Rule{
+			{RuleID: "WN11-CC-000001", Rego: "package stig.wn11cc000001\n"},
+			{RuleID: "WN11-CC-000002"},
+		},
+	}
+
+	tmpl := ExportCategory(category)
+	if tmpl.Kind != "ConstraintTemplate" {
+		t.Errorf("got Kind %q, want ConstraintTemplate", tmpl.Kind)
+	}
+	if tmpl.Spec.CRD.Spec.Names.Kind != "StigWn11Cc" {
+		t.Errorf("got CRD kind %q, want StigWn11Cc", tmpl.Spec.CRD.Spec.Names.Kind)
+	}
+	if len(tmpl.Spec.Targets) != 1 {
+		t.Fatalf("got %d targets, want 1 (rule without Rego should be skipped)", len(tmpl.Spec.Targets))
+	}
+	if tmpl.Spec.Targets[0].Target != "admission.k8s.gatekeeper.sh" {
+		t.Errorf("got target %q, want admission.k8s.gatekeeper.sh", tmpl.Spec.Targets[0].Target)
+	}
+}
+
+func TestExportConstraintUsesDefaultEnforcementMapBySeverity(t *testing.T) {
+	category := schema.Category{ID: "wn11-cc"}
+	rule := schema.Rule{RuleID: "WN11-CC-000001", Severity: schema.SeverityHigh, CCI: "CCI-000366"}
+
+	c := ExportConstraint(category, rule, nil)
+	if c.Kind != "StigWn11Cc" {
+		t.Errorf("got Kind %q, want StigWn11Cc", c.Kind)
+	}
+	if c.Metadata.Name != "wn11-cc-000001" {
+		t.Errorf("got Metadata.Name %q, want wn11-cc-000001", c.Metadata.Name)
+	}
+	if c.Spec.EnforcementAction != "deny" {
+		t.Errorf("got EnforcementAction %q, want deny (default for high severity)", c.Spec.EnforcementAction)
+	}
+	if len(c.Spec.ScopedEnforcementActions) != 2 {
+		t.Fatalf("got %d scoped enforcement actions, want 2 (audit, webhook)", len(c.Spec.ScopedEnforcementActions))
+	}
+	for _, s := range c.Spec.ScopedEnforcementActions {
+		if s.Action != "deny" {
+			t.Errorf("got scoped action %q, want deny", s.Action)
+		}
+	}
+}
+
+func TestExportConstraintEnforcementMapOverridesByRuleIDThenSeverity(t *testing.T) {
+	category := schema.Category{ID: "wn11-cc"}
+	rule := schema.Rule{RuleID: "WN11-CC-000001", Severity: schema.SeverityHigh}
+
+	byRuleID := ExportConstraint(category, rule, map[string]string{"WN11-CC-000001": "dryrun"})
+	if byRuleID.Spec.EnforcementAction != "dryrun" {
+		t.Errorf("got EnforcementAction %q, want dryrun (overridden by rule ID)", byRuleID.Spec.EnforcementAction)
+	}
+
+	bySeverity := ExportConstraint(category, rule, map[string]string{"high": "warn"})
+	if bySeverity.Spec.EnforcementAction != "warn" {
+		t.Errorf("got EnforcementAction %q, want warn (overridden by severity)", bySeverity.Spec.EnforcementAction)
+	}
+}
+
+func TestExportConstraintFallsBackToWarnForUnknownSeverity(t *testing.T) {
+	category := schema.Category{ID: "wn11-cc"}
+	rule := schema.Rule{RuleID: "WN11-CC-000001", Severity: "nonsense"}
+
+	c := ExportConstraint(category, rule, nil)
+	if c.Spec.EnforcementAction != "warn" {
+		t.Errorf("got EnforcementAction %q, want warn fallback", c.Spec.EnforcementAction)
+	}
+}
+
+func TestSanitizeNameHandlesEmptyAndNonAlnum(t *testing.T) {
+	if got := sanitizeName("WN11-CC-000001"); got != "wn11-cc-000001" {
+		t.Errorf("got %q, want wn11-cc-000001", got)
+	}
+	if got := sanitizeName("###"); got != "stig-rule" {
+		t.Errorf("got %q, want stig-rule fallback for an all-non-alphanumeric name", got)
+	}
+}
+
+func TestToPascalCaseJoinsHyphenatedAndUnderscoredParts(t *testing.T) {
+	if got := toPascalCase("wn11-cc"); got != "Wn11Cc" {
+		t.Errorf("got %q, want Wn11Cc", got)
+	}
+	if got := toPascalCase("wn11_cc"); got != "Wn11Cc" {
+		t.Errorf("got %q, want Wn11Cc", got)
+	}
+}
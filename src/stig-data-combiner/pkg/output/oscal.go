@@ -0,0 +1,130 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+// OSCALFormatter renders a whole benchmark run as one NIST OSCAL
+// component-definition document: a single component (this run's generated
+// rule set) with one control-implementation per rule, and one
+// implemented-requirement per control ID that rule maps to.
+//
+// Unlike stig-processor's own OSCAL formatter (which resolves
+// compliance.nist_controls annotations derived from a CCI-to-800-53
+// mapping table), this module carries no such mapping - schema.Rule only
+// has CCI, with no NIST 800-53 cross-reference. Implemented requirements
+// here are keyed directly by Rule.CCI, falling back to Rule.RuleID when a
+// rule has no CCI, same fallback shape as the sibling formatter uses for
+// its own unmapped case.
+//
+// As with stig-processor's formatter, nothing in this module vendors a
+// UUID generator, so identifiers are derived deterministically from rule
+// IDs rather than random - stable and unique within one run, not
+// spec-compliant UUIDs.
+type OSCALFormatter struct{}
+
+func (OSCALFormatter) Format(data *schema.BenchmarkData, w io.Writer) error {
+	doc := oscalComponentDefinition{
+		ComponentDefinition: oscalComponentDefinitionBody{
+			UUID: "component-definition-stig-data-combiner",
+			Metadata: oscalMetadata{
+				Title:   fmt.Sprintf("%s compliance component definition", data.Meta.Title),
+				Version: data.Meta.Version,
+			},
+			Components: []oscalComponent{
+				{
+					UUID:        "component-stig-data-combiner",
+					Type:        "software",
+					Title:       "stig-data-combiner generated benchmark rules",
+					Description: fmt.Sprintf("%d rul%s combined from this benchmark.", countRules(data), pluralE(countRules(data))),
+				},
+			},
+		},
+	}
+
+	for _, category := range data.Categories {
+		for _, rule := range category.Rules {
+			requirements := []oscalImplementedRequirement{{
+				UUID:        "impl-req-" + rule.RuleID + "-" + controlID(&rule),
+				ControlID:   controlID(&rule),
+				Description: rule.Description,
+			}}
+
+			doc.ComponentDefinition.Components[0].ControlImplementations = append(
+				doc.ComponentDefinition.Components[0].ControlImplementations,
+				oscalControlImplementation{
+					UUID:                    "control-impl-" + rule.RuleID,
+					Source:                  "#" + data.Meta.Framework,
+					Description:             rule.FixText,
+					ImplementedRequirements: requirements,
+				},
+			)
+		}
+	}
+
+	return json.NewEncoder(w).Encode(doc)
+}
+
+// controlID returns rule's mapped control ID, falling back to its own STIG
+// rule ID when rule.CCI is empty.
+func controlID(rule *schema.Rule) string {
+	if rule.CCI != "" {
+		return rule.CCI
+	}
+	return rule.RuleID
+}
+
+func countRules(data *schema.BenchmarkData) int {
+	n := 0
+	for _, category := range data.Categories {
+		n += len(category.Rules)
+	}
+	return n
+}
+
+type oscalComponentDefinition struct {
+	ComponentDefinition oscalComponentDefinitionBody `json:"component-definition"`
+}
+
+type oscalComponentDefinitionBody struct {
+	UUID       string           `json:"uuid"`
+	Metadata   oscalMetadata    `json:"metadata"`
+	Components []oscalComponent `json:"components"`
+}
+
+type oscalMetadata struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type oscalComponent struct {
+	UUID                   string                       `json:"uuid"`
+	Type                   string                       `json:"type"`
+	Title                  string                       `json:"title"`
+	Description            string                       `json:"description"`
+	ControlImplementations []oscalControlImplementation `json:"control-implementations"`
+}
+
+type oscalControlImplementation struct {
+	UUID                    string                        `json:"uuid"`
+	Source                  string                        `json:"source"`
+	Description             string                        `json:"description"`
+	ImplementedRequirements []oscalImplementedRequirement `json:"implemented-requirements"`
+}
+
+type oscalImplementedRequirement struct {
+	UUID        string `json:"uuid"`
+	ControlID   string `json:"control-id"`
+	Description string `json:"description"`
+}
+
+func pluralE(n int) string {
+	if n == 1 {
+		return "e"
+	}
+	return "es"
+}
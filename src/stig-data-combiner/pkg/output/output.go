@@ -0,0 +1,46 @@
+// Package output renders a whole combiner run - every schema.Rule in a
+// schema.BenchmarkData together, not the per-host-facing benchmark-data.json
+// the main pipeline already writes - into formats downstream tooling other
+// than the web UI consumes: an aggregate OSCAL component-definition, a
+// CycloneDX VEX document, and a Trivy checks-style Rego rule tree. A run can
+// write benchmark-data.json via the existing pipeline and any of these via
+// -format in the same pass.
+package output
+
+import (
+	"io"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+// Format names -format accepts, beyond "fleet" (a no-op marker meaning "the
+// benchmark-data.json the main pipeline already writes" - included so
+// "-format all" and "-format fleet,oscal" read naturally without this
+// package needing to know anything about how benchmark-data.json is
+// written).
+const (
+	FormatFleet = "fleet"
+	FormatOSCAL = "oscal"
+	FormatVEX   = "vex"
+	FormatTrivy = "trivy"
+)
+
+// AllFormats lists every format "-format all" expands to.
+var AllFormats = []string{FormatFleet, FormatOSCAL, FormatVEX, FormatTrivy}
+
+// Formatter renders an entire benchmark run as a single document written
+// to w. Trivy isn't a Formatter - it writes one .rego file per rule rather
+// than a single document - so it's handled separately by WriteFormats
+// below rather than registered here.
+type Formatter interface {
+	Format(data *schema.BenchmarkData, w io.Writer) error
+}
+
+// Formatters maps a -format name to the Formatter that produces it.
+// Registered here rather than inlined in WriteFormats so a caller needing
+// just one format's bytes (e.g. to stream it somewhere other than a file)
+// can look it up directly.
+var Formatters = map[string]Formatter{
+	FormatOSCAL: OSCALFormatter{},
+	FormatVEX:   VEXFormatter{},
+}
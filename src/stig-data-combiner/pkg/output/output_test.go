@@ -0,0 +1,168 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+func sampleBenchmarkData() *schema.BenchmarkData {
+	return &schema.BenchmarkData{
+		Meta: schema.Meta{Framework: "STIG", Title: "Example Benchmark", Version: "v1r1"},
+		Categories: []schema.Category{
+			{
+				ID:   "example",
+				Name: "Example",
+				Rules: []schema.Rule{
+					{
+						ID:          "V-1",
+						RuleID:      "EX-00-000001",
+						Title:       "An example automatable rule",
+						Severity:    schema.SeverityHigh,
+						FixText:     "Set the registry value.",
+						Automatable: true,
+						CCI:         "CCI-000001",
+					},
+					{
+						ID:          "V-2",
+						RuleID:      "EX-00-000002",
+						Title:       "An example manual-review rule",
+						Severity:    schema.SeverityMedium,
+						Automatable: false,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParseFormats(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want []string
+	}{
+		{"", []string{FormatFleet}},
+		{"oscal", []string{FormatOSCAL}},
+		{"fleet,oscal,vex", []string{FormatFleet, FormatOSCAL, FormatVEX}},
+		{"all", []string{FormatFleet, FormatOSCAL, FormatVEX, FormatTrivy}},
+		{"OSCAL, vex", []string{FormatOSCAL, FormatVEX}},
+	}
+	for _, tc := range cases {
+		got, err := ParseFormats(tc.raw)
+		if err != nil {
+			t.Fatalf("ParseFormats(%q) returned unexpected error: %v", tc.raw, err)
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("ParseFormats(%q) = %v, want %v", tc.raw, got, tc.want)
+		}
+	}
+}
+
+func TestParseFormatsRejectsUnknownToken(t *testing.T) {
+	if _, err := ParseFormats("osacl"); err == nil {
+		t.Fatal("expected ParseFormats to reject an unknown format token instead of silently dropping it")
+	}
+}
+
+func TestOSCALFormatterKeysByCCIWithFallback(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (OSCALFormatter{}).Format(sampleBenchmarkData(), &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var doc oscalComponentDefinition
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to unmarshal OSCAL document: %v", err)
+	}
+
+	impls := doc.ComponentDefinition.Components[0].ControlImplementations
+	if len(impls) != 2 {
+		t.Fatalf("got %d control implementations, want 2", len(impls))
+	}
+
+	var ids []string
+	for _, impl := range impls {
+		for _, req := range impl.ImplementedRequirements {
+			ids = append(ids, req.ControlID)
+		}
+	}
+
+	if !contains(ids, "CCI-000001") {
+		t.Errorf("expected control ID CCI-000001 for the rule with a CCI, got %v", ids)
+	}
+	if !contains(ids, "EX-00-000002") {
+		t.Errorf("expected control ID to fall back to RuleID for the rule with no CCI, got %v", ids)
+	}
+}
+
+func TestVEXFormatterOnlyIncludesAutomatableRules(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (VEXFormatter{}).Format(sampleBenchmarkData(), &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var doc cdxVEXDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to unmarshal VEX document: %v", err)
+	}
+
+	if len(doc.Vulnerabilities) != 1 {
+		t.Fatalf("got %d vulnerabilities, want 1 (only the automatable rule)", len(doc.Vulnerabilities))
+	}
+	if doc.Vulnerabilities[0].ID != "EX-00-000001" {
+		t.Errorf("got vulnerability ID %q, want %q", doc.Vulnerabilities[0].ID, "EX-00-000001")
+	}
+}
+
+func TestWriteTrivyRulesWritesOneFilePerAutomatableRule(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteTrivyRules(sampleBenchmarkData(), dir); err != nil {
+		t.Fatalf("WriteTrivyRules failed: %v", err)
+	}
+
+	path := filepath.Join(dir, "rules", "compliance", "stig", "EX-00-000001.rego")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected a rego file for the automatable rule: %v", err)
+	}
+	if !strings.Contains(string(content), "avd_id: STIG-EX-00-000001") {
+		t.Errorf("rego file missing expected avd_id METADATA: %s", content)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "rules", "compliance", "stig", "EX-00-000002.rego")); !os.IsNotExist(err) {
+		t.Error("did not expect a rego file for the manual-review rule")
+	}
+}
+
+func TestWriteFormatsSkipsFleetAndRejectsUnknownFormat(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := WriteFormats(sampleBenchmarkData(), dir, []string{FormatFleet}); err != nil {
+		t.Fatalf("WriteFormats with only fleet should be a no-op, got: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no files written for -format fleet, got %v", entries)
+	}
+
+	if err := WriteFormats(sampleBenchmarkData(), dir, []string{"bogus"}); err == nil {
+		t.Error("expected an error for an unrecognized format")
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+// WriteTrivyRules writes one standalone .rego check per automatable rule
+// under outputDir/rules/compliance/stig/, in the shape Trivy's config
+// scanner (`trivy config --policy <dir>`) loads directly: a "# METADATA"
+// annotation block naming avd_id/severity/platform, followed by a deny
+// rule. This is a distinct skeleton from rule.Rego (see pkg/rego,
+// chunk1-1) - that module evaluates input.registry directly for
+// Conftest/Gatekeeper, while Trivy's config scanner expects its own
+// input.selector-keyed shape - so the two are written independently
+// rather than one wrapping the other.
+//
+// Every file's content is a deterministic function of data - written in
+// rule.RuleID order - so re-running over the same combined rules
+// reproduces the same tree byte for byte.
+func WriteTrivyRules(data *schema.BenchmarkData, outputDir string) error {
+	rulesDir := filepath.Join(outputDir, "rules", "compliance", "stig")
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", rulesDir, err)
+	}
+
+	var rules []schema.Rule
+	for _, category := range data.Categories {
+		for _, rule := range category.Rules {
+			if rule.Automatable {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].RuleID < rules[j].RuleID
+	})
+
+	for _, rule := range rules {
+		path := filepath.Join(rulesDir, rule.RuleID+".rego")
+		if err := os.WriteFile(path, []byte(trivyRuleSource(&rule)), 0644); err != nil {
+			return fmt.Errorf("failed to write trivy rule %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// trivyRuleSource renders rule as a single Trivy-shaped Rego check. This
+// dataset is Windows-only (see pkg/combiner, which reads win-stig),
+// so platform is always "windows".
+func trivyRuleSource(rule *schema.Rule) string {
+	avdID := "STIG-" + strings.ToUpper(rule.RuleID)
+	severity := strings.ToUpper(rule.Severity)
+	if severity == "" {
+		severity = "UNKNOWN"
+	}
+
+	pkgName := strings.ReplaceAll(strings.ToLower(rule.RuleID), "-", "_")
+
+	var b strings.Builder
+	b.WriteString("# METADATA\n")
+	fmt.Fprintf(&b, "# title: %s\n", commentEscapeLine(rule.Title))
+	b.WriteString("# custom:\n")
+	fmt.Fprintf(&b, "#   id: %s\n", avdID)
+	fmt.Fprintf(&b, "#   avd_id: %s\n", avdID)
+	fmt.Fprintf(&b, "#   severity: %s\n", severity)
+	b.WriteString("#   platform: windows\n")
+	if rule.CCI != "" {
+		fmt.Fprintf(&b, "#   cci: %s\n", rule.CCI)
+	}
+	fmt.Fprintf(&b, "package stig.%s\n\n", pkgName)
+	b.WriteString("import future.keywords.contains\n")
+	b.WriteString("import future.keywords.if\n\n")
+	b.WriteString("deny contains msg if {\n")
+	fmt.Fprintf(&b, "\tnot input.%s\n", pkgName)
+	fmt.Fprintf(&b, "\tmsg := %q\n", commentEscapeLine(rule.FixText))
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// commentEscapeLine strips newlines so text can't break out of a
+// single-line METADATA comment or a %q-quoted message literal.
+func commentEscapeLine(text string) string {
+	return strings.TrimSpace(strings.ReplaceAll(text, "\n", " "))
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+// VEXFormatter renders a whole run as a minimal CycloneDX 1.4 VEX
+// document, one vulnerabilities[] entry per automatable rule, each state
+// "not_affected" with justification "control_not_present" - VEX's way of
+// saying a reported finding doesn't apply because the control this run
+// generated a rule for already addresses it.
+//
+// CycloneDX VEX normally keys each entry off the vulnerability's own
+// CVE/CCE identifier, but schema.Rule carries neither - rules are
+// identified by CCI/rule ID only. Entries here are keyed by RuleID
+// instead; a consumer wanting real CVE cross-references would need to
+// join this document against an external CCI-to-CVE mapping, which this
+// package does not attempt to maintain.
+type VEXFormatter struct{}
+
+func (VEXFormatter) Format(data *schema.BenchmarkData, w io.Writer) error {
+	doc := cdxVEXDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.4",
+		Version:     1,
+	}
+
+	for _, category := range data.Categories {
+		for _, rule := range category.Rules {
+			if !rule.Automatable {
+				continue
+			}
+			doc.Vulnerabilities = append(doc.Vulnerabilities, cdxVulnerability{
+				ID:          rule.RuleID,
+				Description: rule.Title,
+				Analysis: cdxAnalysis{
+					State:         "not_affected",
+					Justification: "control_not_present",
+					Detail:        rule.FixText,
+				},
+				Affects: []cdxAffects{{Ref: rule.ID}},
+			})
+		}
+	}
+
+	return json.NewEncoder(w).Encode(doc)
+}
+
+type cdxVEXDocument struct {
+	BOMFormat       string             `json:"bomFormat"`
+	SpecVersion     string             `json:"specVersion"`
+	Version         int                `json:"version"`
+	Vulnerabilities []cdxVulnerability `json:"vulnerabilities"`
+}
+
+type cdxVulnerability struct {
+	ID          string       `json:"id"`
+	Description string       `json:"description,omitempty"`
+	Analysis    cdxAnalysis  `json:"analysis"`
+	Affects     []cdxAffects `json:"affects"`
+}
+
+type cdxAnalysis struct {
+	State         string `json:"state"`
+	Justification string `json:"justification,omitempty"`
+	Detail        string `json:"detail,omitempty"`
+}
+
+type cdxAffects struct {
+	Ref string `json:"ref"`
+}
@@ -0,0 +1,111 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+// filenames maps a Formatter-backed format to the file WriteFormats writes
+// its output to, under outputDir.
+var filenames = map[string]string{
+	FormatOSCAL: "oscal-component-definition.json",
+	FormatVEX:   "vex.cdx.json",
+}
+
+// ParseFormats splits raw (as accepted by -format: a comma-separated list,
+// or "all") into the formats WriteFormats should produce, deduplicated and
+// in AllFormats order. An empty raw yields just [FormatFleet], matching the
+// existing behavior of always writing benchmark-data.json. A token that
+// names neither "all" nor a member of AllFormats is an error, since a typo
+// in -format should fail the run rather than silently produce fewer files
+// than expected.
+func ParseFormats(raw string) ([]string, error) {
+	if raw == "" {
+		return []string{FormatFleet}, nil
+	}
+
+	known := make(map[string]bool, len(AllFormats))
+	for _, f := range AllFormats {
+		known[f] = true
+	}
+
+	requested := make(map[string]bool)
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" {
+			continue
+		}
+		if f == "all" {
+			for _, all := range AllFormats {
+				requested[all] = true
+			}
+			continue
+		}
+		if !known[f] {
+			return nil, fmt.Errorf("unknown output format %q (known: %s)", f, strings.Join(AllFormats, ", "))
+		}
+		requested[f] = true
+	}
+
+	var formats []string
+	for _, f := range AllFormats {
+		if requested[f] {
+			formats = append(formats, f)
+		}
+	}
+	return formats, nil
+}
+
+// WriteFormats writes data in every format named, into outputDir.
+// FormatFleet is skipped - it names the benchmark-data.json the main
+// pipeline already writes via -output, not an additional artifact this
+// package generates. An unrecognized format is an error rather than
+// silently ignored, since a typo in -format should fail the run, not just
+// produce fewer files than expected.
+func WriteFormats(data *schema.BenchmarkData, outputDir string, formats []string) error {
+	var extra []string
+	for _, f := range formats {
+		if f != FormatFleet {
+			extra = append(extra, f)
+		}
+	}
+	if len(extra) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", outputDir, err)
+	}
+
+	for _, format := range extra {
+		if format == FormatTrivy {
+			if err := WriteTrivyRules(data, outputDir); err != nil {
+				return err
+			}
+			continue
+		}
+
+		formatter, ok := Formatters[format]
+		if !ok {
+			return fmt.Errorf("unknown output format: %s", format)
+		}
+
+		path := filepath.Join(outputDir, filenames[format])
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		err = formatter.Format(data, f)
+		closeErr := f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		if closeErr != nil {
+			return fmt.Errorf("failed to close %s: %w", path, closeErr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package registrypol
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+// ParseDir walks dir and attempts Read on every regular file in it,
+// concatenating whatever checks each yields. Real SYSVOL trees rename or
+// nest Registry.pol exports (Machine/Registry.pol, User/Registry.pol, or
+// a per-extension export naming scheme entirely), so files are
+// identified by their PReg header via Read's own validation, not by
+// name: a file that isn't Registry.pol-shaped (or isn't readable) is
+// silently skipped rather than failing the whole directory.
+func ParseDir(dir string) ([]schema.RegistryCheck, error) {
+	var checks []schema.RegistryCheck
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		fileChecks, err := Read(data)
+		if err != nil {
+			return nil
+		}
+		checks = append(checks, fileChecks...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk GPO directory %s: %w", dir, err)
+	}
+	return checks, nil
+}
+
+// Index looks up parsed Registry.pol checks by registry path and value
+// name, case-insensitively (as Windows registry lookups already are), so
+// a rule's own check-content-parsed RegistryCheck can be confirmed (and
+// have its ExpectedValue filled in) against what a real SYSVOL GPO
+// actually deploys.
+type Index struct {
+	byLocation map[string]schema.RegistryCheck
+}
+
+// NewIndex builds an Index over checks. A later check with the same
+// Path/ValueName replaces an earlier one, matching how a real
+// Registry.pol file order would win on re-application.
+func NewIndex(checks []schema.RegistryCheck) *Index {
+	idx := &Index{byLocation: make(map[string]schema.RegistryCheck, len(checks))}
+	for _, c := range checks {
+		idx.byLocation[locationKey(c.Path, c.ValueName)] = c
+	}
+	return idx
+}
+
+// Lookup returns the RegistryCheck a real GPO deploys at path/valueName,
+// if any.
+func (idx *Index) Lookup(path, valueName string) (schema.RegistryCheck, bool) {
+	c, ok := idx.byLocation[locationKey(path, valueName)]
+	return c, ok
+}
+
+func locationKey(path, valueName string) string {
+	return strings.ToLower(path) + "\x00" + strings.ToLower(valueName)
+}
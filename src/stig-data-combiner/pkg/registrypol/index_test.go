@@ -0,0 +1,55 @@
+package registrypol
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+func TestParseDirSkipsNonRegistryPolFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	polBytes, err := Write([]schema.RegistryCheck{
+		{Path: `SOFTWARE\Policies\Microsoft\Windows\EarlyLaunchAntiMalware`, ValueName: "DriverLoadPolicy", ValueType: "REG_DWORD", ExpectedValue: "8"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Registry.pol"), polBytes, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("not a registry.pol file"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	checks, err := ParseDir(dir)
+	if err != nil {
+		t.Fatalf("ParseDir failed: %v", err)
+	}
+	if len(checks) != 1 {
+		t.Fatalf("got %d checks, want 1 (the readme should be silently skipped)", len(checks))
+	}
+	if checks[0].ValueName != "DriverLoadPolicy" {
+		t.Errorf("got ValueName %q, want DriverLoadPolicy", checks[0].ValueName)
+	}
+}
+
+func TestIndexLookupIsCaseInsensitive(t *testing.T) {
+	idx := NewIndex([]schema.RegistryCheck{
+		{Path: `SOFTWARE\Policies\Microsoft\Windows\EarlyLaunchAntiMalware`, ValueName: "DriverLoadPolicy", ExpectedValue: "8"},
+	})
+
+	check, ok := idx.Lookup(`software\policies\microsoft\windows\earlylaunchantimalware`, "driverloadpolicy")
+	if !ok {
+		t.Fatal("expected a case-insensitive match")
+	}
+	if check.ExpectedValue != "8" {
+		t.Errorf("got ExpectedValue %q, want 8", check.ExpectedValue)
+	}
+
+	if _, ok := idx.Lookup(`SOFTWARE\Other`, "Missing"); ok {
+		t.Error("expected no match for an unconfigured location")
+	}
+}
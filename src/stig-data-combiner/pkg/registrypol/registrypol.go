@@ -0,0 +1,225 @@
+// Package registrypol implements the Windows GPO Registry.pol format so a
+// STIG rule's parsed registry checks can be shipped as a native admin
+// template file that drops directly into SYSVOL\Policies\{GUID}\Machine\,
+// instead of requiring operators to translate the generated XML/PowerShell
+// fixes by hand.
+package registrypol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf16"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+// signature is the 8-byte Registry.pol header: "PReg" followed by a
+// little-endian uint32 version of 1.
+var signature = []byte{'P', 'R', 'e', 'g', 0x01, 0x00, 0x00, 0x00}
+
+// Registry value type codes as used in Registry.pol records.
+const (
+	typeREGSZ       uint32 = 1
+	typeREGBinary   uint32 = 3
+	typeREGDWord    uint32 = 4
+	typeREGMultiSZ  uint32 = 7
+)
+
+var typeCodes = map[string]uint32{
+	"REG_SZ":       typeREGSZ,
+	"REG_BINARY":   typeREGBinary,
+	"REG_DWORD":    typeREGDWord,
+	"REG_MULTI_SZ": typeREGMultiSZ,
+}
+
+var typeNames = map[uint32]string{
+	typeREGSZ:      "REG_SZ",
+	typeREGBinary:  "REG_BINARY",
+	typeREGDWord:   "REG_DWORD",
+	typeREGMultiSZ: "REG_MULTI_SZ",
+}
+
+// Write encodes a set of RegistryCheck values as a Registry.pol document:
+// the 8-byte PReg header followed by one [key;value;type;size;data] record
+// per check, with key/value/separators as UTF-16LE text and type/size as
+// little-endian uint32s ahead of the raw value data.
+func Write(checks []schema.RegistryCheck) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(signature)
+
+	for _, check := range checks {
+		typeCode, ok := typeCodes[check.ValueType]
+		if !ok {
+			typeCode = typeREGSZ
+		}
+
+		data, err := encodeValue(check.ValueType, check.ExpectedValue)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s\\%s: %w", check.Path, check.ValueName, err)
+		}
+
+		writeUTF16(&buf, "[")
+		writeUTF16(&buf, check.Path)
+		writeUTF16(&buf, ";")
+		writeUTF16(&buf, check.ValueName)
+		writeUTF16(&buf, ";")
+		binary.Write(&buf, binary.LittleEndian, typeCode)
+		writeUTF16(&buf, ";")
+		binary.Write(&buf, binary.LittleEndian, uint32(len(data)))
+		writeUTF16(&buf, ";")
+		buf.Write(data)
+		writeUTF16(&buf, "]")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Read decodes a Registry.pol document back into RegistryCheck values
+// (Hive is left empty since Registry.pol keys are hive-relative; callers
+// that need a specific hive should set check.Hive after decoding).
+func Read(data []byte) ([]schema.RegistryCheck, error) {
+	if len(data) < len(signature) || !bytes.Equal(data[:len(signature)], signature) {
+		return nil, fmt.Errorf("invalid Registry.pol header")
+	}
+
+	r := bytes.NewReader(data[len(signature):])
+	var checks []schema.RegistryCheck
+
+	for r.Len() > 0 {
+		if tok, err := readUTF16Token(r); err != nil || tok != "[" {
+			break
+		}
+
+		key, err := readUTF16String(r, ";")
+		if err != nil {
+			return nil, fmt.Errorf("reading key: %w", err)
+		}
+		value, err := readUTF16String(r, ";")
+		if err != nil {
+			return nil, fmt.Errorf("reading value name: %w", err)
+		}
+
+		var typeCode uint32
+		if err := binary.Read(r, binary.LittleEndian, &typeCode); err != nil {
+			return nil, fmt.Errorf("reading type code: %w", err)
+		}
+		if _, err := readUTF16Token(r); err != nil {
+			return nil, err
+		}
+
+		var size uint32
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return nil, fmt.Errorf("reading size: %w", err)
+		}
+		if _, err := readUTF16Token(r); err != nil {
+			return nil, err
+		}
+
+		raw := make([]byte, size)
+		if _, err := io.ReadFull(r, raw); err != nil {
+			return nil, fmt.Errorf("reading data: %w", err)
+		}
+
+		if _, err := readUTF16Token(r); err != nil { // trailing "]"
+			return nil, err
+		}
+
+		valueType := typeNames[typeCode]
+		checks = append(checks, schema.RegistryCheck{
+			Path:          key,
+			ValueName:     value,
+			ValueType:     valueType,
+			ExpectedValue: decodeValue(valueType, raw),
+			Comparison:    "equals",
+		})
+	}
+
+	return checks, nil
+}
+
+// encodeValue renders an expected value as the raw bytes a Registry.pol
+// record expects for the given registry type.
+func encodeValue(valueType, value string) ([]byte, error) {
+	switch valueType {
+	case "REG_DWORD":
+		var n uint32
+		if _, err := fmt.Sscanf(value, "%d", &n); err != nil {
+			return nil, fmt.Errorf("invalid REG_DWORD value %q: %w", value, err)
+		}
+		buf := make([]byte, 4)
+		binary.LittleEndian.PutUint32(buf, n)
+		return buf, nil
+	case "REG_MULTI_SZ":
+		return utf16Bytes(value + "\x00"), nil
+	default: // REG_SZ, REG_BINARY fall back to UTF-16LE text
+		return utf16Bytes(value), nil
+	}
+}
+
+func decodeValue(valueType string, raw []byte) string {
+	if valueType == "REG_DWORD" && len(raw) >= 4 {
+		return fmt.Sprintf("%d", binary.LittleEndian.Uint32(raw))
+	}
+	return utf16BytesToString(raw)
+}
+
+func writeUTF16(buf *bytes.Buffer, s string) {
+	buf.Write(utf16Bytes(s))
+}
+
+func utf16Bytes(s string) []byte {
+	units := utf16.Encode([]rune(s))
+	buf := make([]byte, len(units)*2)
+	for i, u := range units {
+		binary.LittleEndian.PutUint16(buf[i*2:], u)
+	}
+	return buf
+}
+
+func utf16BytesToString(b []byte) string {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	// Trim trailing NUL terminators used by REG_SZ/REG_MULTI_SZ.
+	for len(units) > 0 && units[len(units)-1] == 0 {
+		units = units[:len(units)-1]
+	}
+	return string(utf16.Decode(units))
+}
+
+// readUTF16Token reads a single UTF-16LE code unit and returns it as a
+// string. Callers use it to consume a "[", ";" or "]" separator; the unit
+// read is not checked against those separators here.
+func readUTF16Token(r *bytes.Reader) (string, error) {
+	var u [2]byte
+	if _, err := r.Read(u[:]); err != nil {
+		return "", err
+	}
+	c := rune(binary.LittleEndian.Uint16(u[:]))
+	return string(c), nil
+}
+
+// readUTF16String reads UTF-16LE code units until it encounters the given
+// single-character ASCII separator, returning the decoded string without
+// consuming trailing padding.
+func readUTF16String(r *bytes.Reader, sep string) (string, error) {
+	sepRune := rune(sep[0])
+	var units []uint16
+
+	for {
+		var u [2]byte
+		if _, err := r.Read(u[:]); err != nil {
+			return "", err
+		}
+		c := binary.LittleEndian.Uint16(u[:])
+		if rune(c) == sepRune {
+			break
+		}
+		units = append(units, c)
+	}
+
+	return string(utf16.Decode(units)), nil
+}
@@ -0,0 +1,51 @@
+package registrypol
+
+import (
+	"testing"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+func TestWriteThenReadRoundTrips(t *testing.T) {
+	checks := []schema.RegistryCheck{
+		{Path: `SOFTWARE\Policies\Microsoft\Windows\EarlyLaunchAntiMalware`, ValueName: "DriverLoadPolicy", ValueType: "REG_DWORD", ExpectedValue: "8"},
+		{Path: `SOFTWARE\Policies\Microsoft\Windows\System`, ValueName: "EnableSmartScreen", ValueType: "REG_SZ", ExpectedValue: "1"},
+	}
+
+	data, err := Write(checks)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	decoded, err := Read(data)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(decoded) != 2 {
+		t.Fatalf("got %d checks, want 2", len(decoded))
+	}
+	if decoded[0].ValueName != "DriverLoadPolicy" || decoded[0].ExpectedValue != "8" {
+		t.Errorf("got %+v, want DriverLoadPolicy=8", decoded[0])
+	}
+	if decoded[1].ValueName != "EnableSmartScreen" || decoded[1].ExpectedValue != "1" {
+		t.Errorf("got %+v, want EnableSmartScreen=1", decoded[1])
+	}
+}
+
+func TestReadRejectsTruncatedRecordData(t *testing.T) {
+	checks := []schema.RegistryCheck{
+		{Path: `SOFTWARE\Policies`, ValueName: "DriverLoadPolicy", ValueType: "REG_DWORD", ExpectedValue: "8"},
+	}
+	data, err := Write(checks)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	// Chop off the tail of the file, mid-record-data, so the declared
+	// size field no longer matches the bytes actually available.
+	truncated := data[:len(data)-6]
+
+	if _, err := Read(truncated); err == nil {
+		t.Fatal("expected Read to error on a truncated record instead of silently decoding a partial value")
+	}
+}
@@ -0,0 +1,85 @@
+// Package rego synthesizes OPA Rego modules from parsed registry checks so
+// each schema.Rule can carry a portable evaluation path alongside its
+// osquery SQL, for environments (Conftest, Gatekeeper) where osquery isn't
+// available.
+package rego
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+var pkgNameSanitizer = regexp.MustCompile(`[^a-z0-9]+`)
+
+// GeneratePolicy builds a Rego module for a rule from its parsed registry
+// checks. The module reads host state from input.registry[hive][path][value]
+// and declares a compliant rule (true only when every check holds) plus
+// one message rule: deny[msg] for high severity (for Conftest/Gatekeeper-
+// style "any deny blocks the request" evaluation) and warn[msg] for every
+// other severity.
+func GeneratePolicy(ruleID, severity string, cci string, checks []schema.RegistryCheck) string {
+	if len(checks) == 0 {
+		return ""
+	}
+
+	pkgName := sanitizePackageName(ruleID)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package stig.%s\n\n", pkgName)
+	b.WriteString("default compliant = false\n\n")
+	b.WriteString("compliant {\n")
+	for i, check := range checks {
+		fmt.Fprintf(&b, "\t%s\n", condition(fmt.Sprintf("v%d", i), check))
+	}
+	b.WriteString("}\n\n")
+
+	ruleName := "deny"
+	if !strings.EqualFold(severity, "high") {
+		ruleName = "warn"
+	}
+
+	fmt.Fprintf(&b, "%s[msg] {\n", ruleName)
+	b.WriteString("\tnot compliant\n")
+	fmt.Fprintf(&b, "\tmsg := sprintf(\"%%s (CCI: %%s, severity: %%s) is not compliant\", [%q, %q, %q])\n", ruleID, cci, severity)
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// condition renders one RegistryCheck as a Rego expression, translating the
+// comparison values already computed by the parser (equals, greater_equal,
+// less_equal, not_exists, must_exist) into input.registry lookups, and
+// treating REG_SZ/REG_MULTI_SZ values as strings vs. REG_DWORD/REG_QWORD as
+// numbers.
+func condition(binding string, check schema.RegistryCheck) string {
+	ref := fmt.Sprintf("input.registry[%q][%q][%q]", check.Hive, check.Path, check.ValueName)
+
+	switch check.Comparison {
+	case "not_exists":
+		return fmt.Sprintf("not %s", ref)
+	case "must_exist":
+		return ref
+	case "greater_equal":
+		return fmt.Sprintf("%s := to_number(%s); %s >= %s", binding, ref, binding, check.ExpectedValue)
+	case "less_equal":
+		return fmt.Sprintf("%s := to_number(%s); %s <= %s", binding, ref, binding, check.ExpectedValue)
+	default:
+		if check.ValueType == "REG_SZ" || check.ValueType == "REG_MULTI_SZ" {
+			return fmt.Sprintf("%s == %q", ref, check.ExpectedValue)
+		}
+		return fmt.Sprintf("%s := to_number(%s); %s == %s", binding, ref, binding, check.ExpectedValue)
+	}
+}
+
+// sanitizePackageName converts a rule ID into a valid Rego package segment.
+func sanitizePackageName(ruleID string) string {
+	name := pkgNameSanitizer.ReplaceAllString(strings.ToLower(ruleID), "_")
+	name = strings.Trim(name, "_")
+	if name == "" {
+		name = "rule"
+	}
+	return name
+}
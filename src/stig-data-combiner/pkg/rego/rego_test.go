@@ -0,0 +1,99 @@
+package rego
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stig-data-combiner/pkg/schema"
+)
+
+func TestGeneratePolicyReturnsEmptyForNoChecks(t *testing.T) {
+	if got := GeneratePolicy("WN11-CC-000001", "high", "CCI-000366", nil); got != "" {
+		t.Errorf("got %q, want empty string for no registry checks", got)
+	}
+}
+
+func TestGeneratePolicyUsesDenyForHighSeverityAndWarnOtherwise(t *testing.T) {
+	checks := []schema.RegistryCheck{
+		{Hive: "HKEY_LOCAL_MACHINE", Path: `SOFTWARE\Policies`, ValueName: "DriverLoadPolicy", ValueType: "REG_DWORD", Comparison: "equals", ExpectedValue: "8"},
+	}
+
+	high := GeneratePolicy("WN11-CC-000001", "high", "CCI-000366", checks)
+	if !strings.Contains(high, "deny[msg]") {
+		t.Errorf("got %q, want a deny[msg] rule for high severity", high)
+	}
+	if strings.Contains(high, "warn[msg]") {
+		t.Errorf("got %q, want no warn[msg] rule for high severity", high)
+	}
+
+	medium := GeneratePolicy("WN11-CC-000001", "medium", "CCI-000366", checks)
+	if !strings.Contains(medium, "warn[msg]") {
+		t.Errorf("got %q, want a warn[msg] rule for medium severity", medium)
+	}
+	if strings.Contains(medium, "deny[msg]") {
+		t.Errorf("got %q, want no deny[msg] rule for medium severity", medium)
+	}
+}
+
+func TestGeneratePolicyIncludesSanitizedPackageName(t *testing.T) {
+	got := GeneratePolicy("WN11-CC-000001", "high", "CCI-000366", []schema.RegistryCheck{
+		{Hive: "HKEY_LOCAL_MACHINE", Path: `x`, ValueName: "y", Comparison: "must_exist"},
+	})
+	if !strings.Contains(got, "package stig.wn11_cc_000001") {
+		t.Errorf("got %q, want package stig.wn11_cc_000001", got)
+	}
+}
+
+func TestConditionRendersEachComparison(t *testing.T) {
+	cases := []struct {
+		name  string
+		check schema.RegistryCheck
+		want  string
+	}{
+		{
+			name:  "not_exists",
+			check: schema.RegistryCheck{Hive: "HKEY_LOCAL_MACHINE", Path: "x", ValueName: "y", Comparison: "not_exists"},
+			want:  `not input.registry["HKEY_LOCAL_MACHINE"]["x"]["y"]`,
+		},
+		{
+			name:  "must_exist",
+			check: schema.RegistryCheck{Hive: "HKEY_LOCAL_MACHINE", Path: "x", ValueName: "y", Comparison: "must_exist"},
+			want:  `input.registry["HKEY_LOCAL_MACHINE"]["x"]["y"]`,
+		},
+		{
+			name:  "greater_equal",
+			check: schema.RegistryCheck{Hive: "HKEY_LOCAL_MACHINE", Path: "x", ValueName: "y", Comparison: "greater_equal", ExpectedValue: "3"},
+			want:  ">= 3",
+		},
+		{
+			name:  "less_equal",
+			check: schema.RegistryCheck{Hive: "HKEY_LOCAL_MACHINE", Path: "x", ValueName: "y", Comparison: "less_equal", ExpectedValue: "3"},
+			want:  "<= 3",
+		},
+		{
+			name:  "equals string",
+			check: schema.RegistryCheck{Hive: "HKEY_LOCAL_MACHINE", Path: "x", ValueName: "y", Comparison: "equals", ValueType: "REG_SZ", ExpectedValue: "enabled"},
+			want:  `== "enabled"`,
+		},
+		{
+			name:  "equals numeric",
+			check: schema.RegistryCheck{Hive: "HKEY_LOCAL_MACHINE", Path: "x", ValueName: "y", Comparison: "equals", ValueType: "REG_DWORD", ExpectedValue: "8"},
+			want:  "== 8",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := condition("v0", tc.check)
+			if !strings.Contains(got, tc.want) {
+				t.Errorf("condition(%+v) = %q, want it to contain %q", tc.check, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSanitizePackageNameFallsBackToRuleForEmptyInput(t *testing.T) {
+	if got := sanitizePackageName("###"); got != "rule" {
+		t.Errorf("got %q, want rule fallback for an all-non-alphanumeric rule ID", got)
+	}
+}
@@ -0,0 +1,20 @@
+package regobundle
+
+import (
+	"regexp"
+	"strings"
+)
+
+// builtin is a two-argument predicate callable from an "automatable"
+// rule body: builtin(<bound local>, "<literal>").
+type builtin func(value, literal string) bool
+
+var builtins = map[string]builtin{
+	"contains": strings.Contains,
+	"matches": func(value, literal string) bool {
+		matched, err := regexp.MatchString(literal, value)
+		return err == nil && matched
+	},
+	"equals":     func(value, literal string) bool { return value == literal },
+	"startswith": strings.HasPrefix,
+}
@@ -0,0 +1,15 @@
+package regobundle
+
+// groupFields is the fixed set of Group fields an "import
+// input.group.<Field>" declaration may reference.
+var groupFields = map[string]func(Group) string{
+	"GroupID":            func(g Group) string { return g.GroupID },
+	"RuleID":             func(g Group) string { return g.RuleID },
+	"RuleVersion":        func(g Group) string { return g.RuleVersion },
+	"RuleTitle":          func(g Group) string { return g.RuleTitle },
+	"RuleSeverity":       func(g Group) string { return g.RuleSeverity },
+	"RuleVulnDiscussion": func(g Group) string { return g.RuleVulnDiscussion },
+	"RuleCheckContent":   func(g Group) string { return g.RuleCheckContent },
+	"RuleFixText":        func(g Group) string { return g.RuleFixText },
+	"RuleIdent":          func(g Group) string { return g.RuleIdent },
+}
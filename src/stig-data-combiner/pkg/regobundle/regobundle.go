@@ -0,0 +1,192 @@
+// Package regobundle evaluates STIGGroups against a directory of
+// externally supplied ".rego"-shaped decision files, so an operator can
+// override a rule's fleet_policy decisions (automatable/query/tags/
+// severity) and its platform decision without recompiling
+// stig-data-combiner. This is distinct from pkg/rego.GeneratePolicy,
+// which synthesizes a Rego module *from* a rule's already-parsed
+// registry checks as an alternate evaluation path for the output bundle
+// (Conftest/Gatekeeper); this package instead *evaluates*
+// operator-authored Rego-shaped input against the STIG source data
+// itself, the way stig-processor's pkg/policyengine does for its own
+// STIGGroup type.
+//
+// A real Rego bundle's decisions live at paths like data.stig.platform
+// and data.stig.fleet_policy; this package mirrors that split across
+// exactly two statements available to every compiled file - "platform"
+// and the existing "automatable { ... }"/"query"/"tags"/"severity"
+// fleet_policy statements - rather than implementing real package-path
+// addressing, since a single bundle file already names both a platform
+// and a fleet_policy decision for the same rule in practice.
+//
+// Like pkg/policyengine, this package does not vendor (or reimplement) a
+// full OPA/Rego evaluator. It understands a small, deliberately
+// restricted subset of Rego's shape - a package declaration, "import
+// input.group.<Field>" declarations, scalar/array assignments, and a
+// single "automatable { ... }" rule body built from builtin predicate
+// calls (contains, matches, equals, startswith) - compiled with strict
+// mode enabled: an import that's never referenced, or a local variable
+// bound but never used in a condition, fails compilation. Unlike
+// policyengine there is no separate Capabilities gate restricting which
+// builtins a file may call - this package has exactly one caller
+// (-rego-bundle), not policyengine's multi-deployment story, so every
+// builtin this package implements is always available.
+package regobundle
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//go:embed embedded/*.rego
+var embeddedBundle embed.FS
+
+// Group is the subset of combiner.STIGGroup a compiled policy may
+// reference via "import input.group.<Field>".
+type Group struct {
+	GroupID            string
+	RuleID             string
+	RuleVersion        string
+	RuleTitle          string
+	RuleSeverity       string
+	RuleVulnDiscussion string
+	RuleCheckContent   string
+	RuleFixText        string
+	RuleIdent          string
+}
+
+// RuleDecision is the structured output of evaluating a Group against the
+// compiled bundle. Automatable/Query/Tags/Severity together are the
+// fleet_policy decision surface (data.stig.fleet_policy in a real
+// bundle); Platform is the separate platform decision surface
+// (data.stig.platform).
+type RuleDecision struct {
+	GroupID     string
+	Automatable bool
+	Query       string
+	Tags        []string
+	// Severity overrides the rule's severity (e.g. "high") the same way
+	// a fix file's custom.severity_override annotation does. Empty
+	// leaves the severity the combiner already resolved unchanged.
+	Severity string
+	// Platform overrides the rule's platform (e.g. "windows", "linux").
+	// Empty leaves the rule unscoped, same as before this decision
+	// surface existed.
+	Platform string
+}
+
+// Engine holds the compiled policy set used to evaluate Groups.
+type Engine struct {
+	policies []*compiledPolicy
+}
+
+// NewBundle compiles every .rego file in dir, or the embedded default
+// bundle when dir is empty - which makes no decisions at all (see
+// embedded/default.rego), reproducing pre-rego-bundle combiner behavior
+// exactly. A file that fails strict-mode compilation does not abort the
+// whole bundle: it's skipped, and its compile error is returned alongside
+// whatever policies did compile, so a caller can log a warning (see
+// Combiner.SetRegoBundle) without losing the rest of the bundle.
+func NewBundle(dir string) (*Engine, []error, error) {
+	sources, err := loadSources(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	engine := &Engine{}
+	var compileErrors []error
+
+	for _, name := range names {
+		cp, err := compile(name, sources[name])
+		if err != nil {
+			compileErrors = append(compileErrors, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		engine.policies = append(engine.policies, cp)
+	}
+
+	return engine, compileErrors, nil
+}
+
+// loadSources reads every *.rego file from dir, or the embedded default
+// bundle when dir is empty.
+func loadSources(dir string) (map[string]string, error) {
+	if dir == "" {
+		entries, err := embeddedBundle.ReadDir("embedded")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read embedded rego bundle: %w", err)
+		}
+		sources := make(map[string]string, len(entries))
+		for _, entry := range entries {
+			data, err := embeddedBundle.ReadFile(filepath.Join("embedded", entry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read embedded rego bundle file %s: %w", entry.Name(), err)
+			}
+			sources[entry.Name()] = string(data)
+		}
+		return sources, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rego bundle directory %s: %w", dir, err)
+	}
+
+	sources := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".rego") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rego bundle file %s: %w", entry.Name(), err)
+		}
+		sources[entry.Name()] = string(data)
+	}
+
+	return sources, nil
+}
+
+// Evaluate runs every compiled policy against group, merging their
+// decisions: Automatable is true if any policy says so, Tags are the
+// deduplicated union, and Query/Severity/Platform take the first
+// non-empty value in policy file order.
+func (e *Engine) Evaluate(group Group) RuleDecision {
+	decision := RuleDecision{GroupID: group.GroupID}
+
+	seenTag := make(map[string]bool)
+
+	for _, cp := range e.policies {
+		result := cp.evaluate(group)
+
+		if result.automatable {
+			decision.Automatable = true
+		}
+		if decision.Query == "" {
+			decision.Query = result.query
+		}
+		if decision.Severity == "" {
+			decision.Severity = result.severity
+		}
+		if decision.Platform == "" {
+			decision.Platform = result.platform
+		}
+		for _, tag := range result.tags {
+			if !seenTag[tag] {
+				seenTag[tag] = true
+				decision.Tags = append(decision.Tags, tag)
+			}
+		}
+	}
+
+	return decision
+}
@@ -0,0 +1,131 @@
+package regobundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeBundle(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestNewBundlePromotesMatchingRule(t *testing.T) {
+	dir := writeBundle(t, map[string]string{
+		"promote.rego": `package stig.promote
+import input.group.RuleVersion
+default automatable = false
+automatable {
+	v := input.group.RuleVersion
+	startswith(v, "WN11-CC")
+}
+query := "SELECT 1 FROM registry WHERE path = 'x';"
+tags := ["custom-bundle"]
+severity := "high"
+`,
+	})
+
+	engine, compileErrors, err := NewBundle(dir)
+	if err != nil {
+		t.Fatalf("NewBundle failed: %v", err)
+	}
+	if len(compileErrors) != 0 {
+		t.Fatalf("unexpected compile errors: %v", compileErrors)
+	}
+
+	decision := engine.Evaluate(Group{RuleVersion: "WN11-CC-000001"})
+	if !decision.Automatable {
+		t.Fatal("expected rule to be promoted to automatable")
+	}
+	if decision.Severity != "high" {
+		t.Errorf("got severity %q, want high", decision.Severity)
+	}
+	if len(decision.Tags) != 1 || decision.Tags[0] != "custom-bundle" {
+		t.Errorf("got tags %v, want [custom-bundle]", decision.Tags)
+	}
+
+	decision = engine.Evaluate(Group{RuleVersion: "WN11-SO-000001"})
+	if decision.Automatable {
+		t.Fatal("expected non-matching rule to remain unpromoted")
+	}
+}
+
+func TestNewBundleSkipsFileWithUnusedImport(t *testing.T) {
+	dir := writeBundle(t, map[string]string{
+		"bad.rego": `package stig.bad
+import input.group.RuleVersion
+default automatable = false
+`,
+		"good.rego": `package stig.good
+import input.group.RuleTitle
+default automatable = false
+automatable {
+	t := input.group.RuleTitle
+	contains(t, "firewall")
+}
+`,
+	})
+
+	engine, compileErrors, err := NewBundle(dir)
+	if err != nil {
+		t.Fatalf("NewBundle failed: %v", err)
+	}
+	if len(compileErrors) != 1 {
+		t.Fatalf("got %d compile errors, want 1 (only bad.rego)", len(compileErrors))
+	}
+	if len(engine.policies) != 1 {
+		t.Fatalf("got %d compiled policies, want 1 (good.rego only)", len(engine.policies))
+	}
+
+	decision := engine.Evaluate(Group{RuleTitle: "A host-based firewall must be enabled"})
+	if !decision.Automatable {
+		t.Fatal("expected good.rego's policy to still take effect")
+	}
+}
+
+func TestNewBundlePlatformDecisionIsIndependentOfFleetPolicy(t *testing.T) {
+	dir := writeBundle(t, map[string]string{
+		"linux.rego": `package stig.platform
+default automatable = false
+platform := "linux"
+`,
+	})
+
+	engine, compileErrors, err := NewBundle(dir)
+	if err != nil {
+		t.Fatalf("NewBundle failed: %v", err)
+	}
+	if len(compileErrors) != 0 {
+		t.Fatalf("unexpected compile errors: %v", compileErrors)
+	}
+
+	decision := engine.Evaluate(Group{RuleTitle: "anything"})
+	if decision.Platform != "linux" {
+		t.Errorf("got platform %q, want linux", decision.Platform)
+	}
+	if decision.Automatable {
+		t.Error("platform decision should not also promote the rule to automatable")
+	}
+}
+
+func TestEmbeddedDefaultBundleMakesNoDecisions(t *testing.T) {
+	engine, compileErrors, err := NewBundle("")
+	if err != nil {
+		t.Fatalf("NewBundle(\"\") failed: %v", err)
+	}
+	if len(compileErrors) != 0 {
+		t.Fatalf("embedded default bundle should compile cleanly, got: %v", compileErrors)
+	}
+
+	decision := engine.Evaluate(Group{RuleVersion: "WN11-CC-000001", RuleTitle: "anything"})
+	if decision.Automatable || decision.Query != "" || decision.Severity != "" || decision.Platform != "" || len(decision.Tags) != 0 {
+		t.Errorf("expected embedded default bundle to yield a zero-value decision, got %+v", decision)
+	}
+}
@@ -12,6 +12,11 @@ type Meta struct {
 	Title       string `json:"title"`       // e.g., "Windows 11 Security Technical Implementation Guide"
 	Version     string `json:"version"`     // e.g., "v2r2"
 	GeneratedAt string `json:"generatedAt"` // ISO timestamp
+
+	// Provenance maps each trust-verified source (keyed like "disa:<file>",
+	// "winstig:<file>", "fix:<file>") to the fingerprint of the key that
+	// signed it. Empty unless a trust policy was configured; see pkg/trust.
+	Provenance map[string]string `json:"provenance,omitempty"`
 }
 
 // Category groups related rules together
@@ -37,6 +42,7 @@ type Rule struct {
 	// Automation
 	Automatable bool   `json:"automatable"`
 	Query       string `json:"query,omitempty"` // osquery SQL (if automatable)
+	Rego        string `json:"rego,omitempty"`  // OPA Rego module (whenever registry checks were parsed)
 
 	// Linked fix file
 	Fix *Fix `json:"fix,omitempty"`
@@ -48,13 +54,24 @@ type Rule struct {
 	CCI    string   `json:"cci,omitempty"`
 	Weight string   `json:"weight,omitempty"`
 	Tags   []string `json:"tags"`
+
+	// Platform optionally scopes this rule to a specific OS/platform
+	// (e.g. "windows"), set by an operator's rego bundle (see
+	// pkg/regobundle's platform decision surface). Empty means
+	// unscoped - the STIG's inherent platform (currently always
+	// Windows, per the "Windows11" Tags entry every rule already gets).
+	Platform string `json:"platform,omitempty"`
+
+	// Annotations holds custom.* fields merged in from a fix file's
+	// "# METADATA" block (see pkg/annotations), e.g. custom.cci.
+	Annotations map[string]any `json:"annotations,omitempty"`
 }
 
 // Fix represents a remediation script/config file
 type Fix struct {
 	Filename string `json:"filename"` // e.g., "SolicitedRemoteAssistance.xml"
-	Type     string `json:"type"`     // "xml" or "ps1"
-	Content  string `json:"content"`  // Embedded file content
+	Type     string `json:"type"`     // "xml", "ps1", or "pol" (see Fix types below)
+	Content  string `json:"content"`  // Embedded file content; base64-encoded for binary types like "pol"
 }
 
 // RegistryCheck represents a Windows registry check
@@ -76,6 +93,7 @@ const (
 
 // Fix types
 const (
-	FixTypeXML        = "xml"
-	FixTypePowerShell = "ps1"
+	FixTypeXML         = "xml"
+	FixTypePowerShell  = "ps1"
+	FixTypeRegistryPol = "pol" // Windows GPO Registry.pol, base64-encoded in Fix.Content
 )
@@ -0,0 +1,113 @@
+package trust
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestBundle(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "benchmark-data.json"), []byte(`{"meta":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "oscal-component-definition.json"), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSignBundleThenVerifyBundleAccepts(t *testing.T) {
+	dir := t.TempDir()
+	writeTestBundle(t, dir)
+
+	priv, pub := generateTestKey(t)
+	keyPath := filepath.Join(dir, "..", "sign.key")
+	if err := os.WriteFile(keyPath, []byte(base64.StdEncoding.EncodeToString(priv)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SignBundle(dir, keyPath); err != nil {
+		t.Fatalf("SignBundle failed: %v", err)
+	}
+
+	policy := &PolicyContent{
+		Default: RepoContent{{
+			Type:    TypeSignedBy,
+			KeyType: KeyTypeEd25519PublicKey,
+			KeyData: base64.StdEncoding.EncodeToString(pub),
+		}},
+	}
+	policyPath := filepath.Join(dir, "..", "policy.json")
+	writePolicy(t, policyPath, policy)
+
+	if err := VerifyBundle(dir, policyPath); err != nil {
+		t.Fatalf("VerifyBundle rejected a validly signed bundle: %v", err)
+	}
+}
+
+func TestVerifyBundleRejectsTamperedFile(t *testing.T) {
+	dir := t.TempDir()
+	writeTestBundle(t, dir)
+
+	priv, pub := generateTestKey(t)
+	keyPath := filepath.Join(dir, "..", "sign.key")
+	if err := os.WriteFile(keyPath, []byte(base64.StdEncoding.EncodeToString(priv)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SignBundle(dir, keyPath); err != nil {
+		t.Fatalf("SignBundle failed: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "benchmark-data.json"), []byte(`{"meta":{"tampered":true}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &PolicyContent{
+		Default: RepoContent{{
+			Type:    TypeSignedBy,
+			KeyType: KeyTypeEd25519PublicKey,
+			KeyData: base64.StdEncoding.EncodeToString(pub),
+		}},
+	}
+	policyPath := filepath.Join(dir, "..", "policy.json")
+	writePolicy(t, policyPath, policy)
+
+	if err := VerifyBundle(dir, policyPath); err == nil {
+		t.Fatal("expected VerifyBundle to reject a bundle modified after signing")
+	}
+}
+
+func TestVerifyBundleRejectsUnsignedBundleUnderRejectPolicy(t *testing.T) {
+	dir := t.TempDir()
+	writeTestBundle(t, dir)
+
+	priv, _ := generateTestKey(t)
+	keyPath := filepath.Join(dir, "..", "sign.key")
+	if err := os.WriteFile(keyPath, []byte(base64.StdEncoding.EncodeToString(priv)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SignBundle(dir, keyPath); err != nil {
+		t.Fatalf("SignBundle failed: %v", err)
+	}
+
+	policy := &PolicyContent{Default: RepoContent{{Type: TypeReject}}}
+	policyPath := filepath.Join(dir, "..", "policy.json")
+	writePolicy(t, policyPath, policy)
+
+	if err := VerifyBundle(dir, policyPath); err == nil {
+		t.Fatal("expected VerifyBundle to fail a bundle under a reject policy")
+	}
+}
+
+func writePolicy(t *testing.T, path string, policy *PolicyContent) {
+	t.Helper()
+	data, err := json.Marshal(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
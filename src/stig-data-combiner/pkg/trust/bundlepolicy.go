@@ -0,0 +1,73 @@
+package trust
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// PolicyRequirement is one signature requirement guarding a signed output
+// bundle: Type alone is enough for insecureAcceptAnything/reject; signedBy
+// additionally needs KeyType and one of KeyPath/KeyData. SignedIdentity, if
+// set, restricts this requirement to a bundle whose signature manifest
+// names that identity (see DigestManifest.Identity) - empty accepts any
+// identity.
+//
+// This is deliberately a separate type from Requirement (which gates
+// combiner input sources): a bundle policy is scoped by STIG framework
+// identity rather than by source-path prefix, and needs the extra
+// SignedIdentity field Requirement has no use for.
+type PolicyRequirement struct {
+	Type           string `json:"type"`
+	KeyType        string `json:"keyType,omitempty"`
+	KeyPath        string `json:"keyPath,omitempty"`
+	KeyData        string `json:"keyData,omitempty"`
+	SignedIdentity string `json:"signedIdentity,omitempty"`
+}
+
+// RepoContent is the ordered list of requirements guarding one scope (or
+// PolicyContent.Default), named to match containers/image's policy.json
+// vocabulary.
+type RepoContent []PolicyRequirement
+
+// TransportsContent maps a scope name to the RepoContent guarding it. A
+// scope here names a STIG framework a bundle's DigestManifest.Identity may
+// claim (e.g. "disa-stig"), letting different frameworks require different
+// signing keys.
+type TransportsContent map[string]RepoContent
+
+// PolicyContent is an output bundle trust policy file's top-level shape
+// (-trust-policy on the verify subcommand, -bundle-trust-policy on a
+// combiner run): Default applies to any scope Transports doesn't name
+// explicitly.
+type PolicyContent struct {
+	Default    RepoContent       `json:"default"`
+	Transports TransportsContent `json:"transports,omitempty"`
+}
+
+// LoadPolicy reads and parses a PolicyContent from path.
+func LoadPolicy(path string) (*PolicyContent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle trust policy %s: %w", path, err)
+	}
+	var policy PolicyContent
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle trust policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
+
+// ResolveRequirements returns the RepoContent guarding scope: an exact
+// Transports match if one exists, otherwise Default.
+func (p *PolicyContent) ResolveRequirements(scope string) RepoContent {
+	if p == nil {
+		return nil
+	}
+	if scope != "" {
+		if req, ok := p.Transports[scope]; ok {
+			return req
+		}
+	}
+	return p.Default
+}
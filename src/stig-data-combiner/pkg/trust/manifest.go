@@ -0,0 +1,89 @@
+package trust
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ManifestFilename is the detached signature manifest SignBundle writes
+// into an output bundle directory and VerifyBundle checks it against.
+const ManifestFilename = "signature-manifest.json"
+
+// FileDigest is one file's SHA-256 digest, relative to the bundle
+// directory it was computed in.
+type FileDigest struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// DigestManifest lists every file in a signed output bundle alongside its
+// SHA-256 digest, plus (once SignBundle has run) the signature over that
+// file list. Identity, if set, is the STIG framework this bundle claims
+// to be - matched against a PolicyRequirement's SignedIdentity during
+// verification.
+type DigestManifest struct {
+	Identity  string       `json:"identity,omitempty"`
+	Files     []FileDigest `json:"files"`
+	KeyType   string       `json:"keyType,omitempty"`
+	Signature string       `json:"signature,omitempty"`
+}
+
+// BuildManifest walks dir and computes a FileDigest for every regular file
+// in it, sorted by path for determinism. The manifest file itself
+// (ManifestFilename), if already present from a prior SignBundle run, is
+// skipped so re-signing doesn't fold the old manifest's bytes into the
+// new one.
+func BuildManifest(dir string) ([]FileDigest, error) {
+	var files []FileDigest
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == ManifestFilename {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		sum := sha256.Sum256(data)
+		files = append(files, FileDigest{Path: rel, SHA256: hex.EncodeToString(sum[:])})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk bundle directory %s: %w", dir, err)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// signedBytes returns the canonical byte sequence a DigestManifest's
+// Signature is computed over: identity, then each file's path and
+// digest, in the already-sorted order BuildManifest produces. Excludes
+// KeyType/Signature themselves, which don't exist yet when signing and
+// must be ignored (not just absent) when verifying.
+func signedBytes(identity string, files []FileDigest) []byte {
+	h := sha256.New()
+	h.Write([]byte(identity))
+	for _, f := range files {
+		h.Write([]byte{0})
+		h.Write([]byte(f.Path))
+		h.Write([]byte{0})
+		h.Write([]byte(f.SHA256))
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,77 @@
+package trust
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// LoadOrDefault reads the policy at path, returning a policy containing a
+// single insecureAcceptAnything default requirement if the file doesn't
+// exist yet (mirroring podman's behavior of treating an absent trust store
+// as "trust everything" until an operator locks it down).
+func LoadOrDefault(path string) (*Policy, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return &Policy{Default: []Requirement{{Type: TypeInsecureAcceptAnything}}}, nil
+	}
+	return Load(path)
+}
+
+// Save writes the policy to path as indented JSON.
+func Save(path string, p *Policy) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal trust policy: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write trust policy %s: %w", path, err)
+	}
+	return nil
+}
+
+// SetDefault replaces the policy's default requirement.
+func (p *Policy) SetDefault(req Requirement) {
+	p.Default = []Requirement{req}
+}
+
+// SetSource replaces the requirements for the given source prefix (e.g.
+// "disa:", "winstig:", "fix:").
+func (p *Policy) SetSource(prefix string, req Requirement) {
+	if p.Sources == nil {
+		p.Sources = make(map[string][]Requirement)
+	}
+	p.Sources[prefix] = []Requirement{req}
+}
+
+// Show renders the policy as a podman-trust-style table.
+func (p *Policy) Show() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-20s %-24s %s\n", "SCOPE", "TYPE", "DETAILS")
+	writeRow(&b, "default", p.Default)
+
+	prefixes := make([]string, 0, len(p.Sources))
+	for prefix := range p.Sources {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		writeRow(&b, prefix, p.Sources[prefix])
+	}
+
+	return b.String()
+}
+
+func writeRow(b *strings.Builder, scope string, reqs []Requirement) {
+	for _, req := range reqs {
+		details := ""
+		if req.Type == TypeSignedBy {
+			details = fmt.Sprintf("keyType=%s keyPath=%s", req.KeyType, req.KeyPath)
+			if req.KeyPath == "" {
+				details = fmt.Sprintf("keyType=%s keyData=<inline>", req.KeyType)
+			}
+		}
+		fmt.Fprintf(b, "%-20s %-24s %s\n", scope, req.Type, details)
+	}
+}
@@ -0,0 +1,209 @@
+// Package trust implements two independent trust stores, both modeled on
+// podman's policy.json: Policy/Requirement gate which STIG input sources
+// (disa:, winstig:, fix: path prefixes) the combiner will read, verifying
+// a signature against a sibling "<path>.sig" file; PolicyContent/
+// RepoContent/TransportsContent (see bundlepolicy.go/bundle.go) instead
+// gate the combiner's own benchmark-data.json output, scoped by STIG
+// framework identity rather than source prefix, verified against a
+// DigestManifest of every file in the output directory. They share the
+// Type/KeyType vocabulary below but are otherwise unrelated - a trust
+// decision about where rules came from doesn't imply anything about who's
+// allowed to consume the combined result.
+//
+// Real GPG verification needs a dependency this module doesn't vendor, so
+// signedBy in both stores verifies an Ed25519 detached signature
+// (keyData/keyPath holds the raw public key, base64-encoded) rather than
+// parsing OpenPGP packets. The fingerprint recorded in
+// schema.Meta.Provenance is the SHA-256 digest of the public key, printed
+// in GPG fingerprint style.
+package trust
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Requirement types.
+const (
+	TypeInsecureAcceptAnything = "insecureAcceptAnything"
+	TypeSignedBy               = "signedBy"
+	TypeReject                 = "reject"
+)
+
+// KeyType values a Requirement.KeyType may hold. GPGKeys is accepted for
+// podman policy.json compatibility but not implemented - verifySignedBy
+// rejects it rather than silently treating it as something else. The
+// working key type is KeyTypeEd25519PublicKey; see this package's doc
+// comment for why it exists instead of real GPG/OpenPGP verification.
+const (
+	KeyTypeGPGKeys          = "GPGKeys"
+	KeyTypeEd25519PublicKey = "ed25519PublicKey"
+)
+
+// Requirement is one trust rule: how to treat a source matched by "default"
+// or a source-prefix entry under Sources.
+type Requirement struct {
+	Type    string `json:"type"`
+	KeyType string `json:"keyType,omitempty"`
+	KeyPath string `json:"keyPath,omitempty"`
+	KeyData string `json:"keyData,omitempty"`
+}
+
+// Policy is the on-disk trust document, conventionally named policy.json.
+type Policy struct {
+	Default []Requirement            `json:"default"`
+	Sources map[string][]Requirement `json:"sources,omitempty"`
+}
+
+// Load reads and parses a policy.json trust file.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust policy %s: %w", path, err)
+	}
+
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse trust policy %s: %w", path, err)
+	}
+	if len(p.Default) == 0 {
+		return nil, fmt.Errorf("trust policy %s has no default requirement", path)
+	}
+
+	return &p, nil
+}
+
+// RequirementsFor returns the requirements that apply to sourceKey (e.g.
+// "disa:microsoft-windows-11-....json"), preferring the longest matching
+// entry in Sources over Default.
+func (p *Policy) RequirementsFor(sourceKey string) []Requirement {
+	var bestPrefix string
+	var best []Requirement
+
+	for prefix, reqs := range p.Sources {
+		if strings.HasPrefix(sourceKey, prefix) && len(prefix) > len(bestPrefix) {
+			bestPrefix = prefix
+			best = reqs
+		}
+	}
+
+	if best != nil {
+		return best
+	}
+	return p.Default
+}
+
+// Verify checks path against reqs, locating a sibling "<path>.sig" file
+// when a signedBy requirement demands one. It returns the fingerprint of
+// the key that verified the content, or "" when the requirement was
+// insecureAcceptAnything.
+func Verify(path string, reqs []Requirement) (string, error) {
+	if len(reqs) == 0 {
+		return "", fmt.Errorf("no trust requirement configured for %s", path)
+	}
+
+	var lastErr error
+	for _, req := range reqs {
+		fingerprint, err := verifyOne(path, req)
+		if err == nil {
+			return fingerprint, nil
+		}
+		lastErr = err
+	}
+	return "", lastErr
+}
+
+func verifyOne(path string, req Requirement) (string, error) {
+	switch req.Type {
+	case TypeInsecureAcceptAnything:
+		return "", nil
+	case TypeReject:
+		return "", fmt.Errorf("source %s is rejected by trust policy", path)
+	case TypeSignedBy:
+		return verifySignedBy(path, req)
+	default:
+		return "", fmt.Errorf("unknown trust requirement type %q", req.Type)
+	}
+}
+
+func verifySignedBy(path string, req Requirement) (string, error) {
+	if req.KeyType != KeyTypeEd25519PublicKey {
+		return "", fmt.Errorf("unsupported keyType %q (only %q is implemented in this build)", req.KeyType, KeyTypeEd25519PublicKey)
+	}
+
+	pubKey, err := loadKey(req)
+	if err != nil {
+		return "", err
+	}
+	if len(pubKey) != ed25519.PublicKeySize {
+		return "", fmt.Errorf("key for %s is not a valid %d-byte Ed25519 public key", path, ed25519.PublicKeySize)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	sigPath := path + ".sig"
+	sigB64, err := os.ReadFile(sigPath)
+	if err != nil {
+		return "", fmt.Errorf("missing signature %s: %w", sigPath, err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sigB64)))
+	if err != nil {
+		return "", fmt.Errorf("malformed signature %s: %w", sigPath, err)
+	}
+
+	if !ed25519.Verify(pubKey, content, sig) {
+		return "", fmt.Errorf("signature verification failed for %s", path)
+	}
+
+	return fingerprint(pubKey), nil
+}
+
+func loadKey(req Requirement) (ed25519.PublicKey, error) {
+	var raw string
+	switch {
+	case req.KeyData != "":
+		raw = req.KeyData
+	case req.KeyPath != "":
+		data, err := os.ReadFile(req.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %s: %w", req.KeyPath, err)
+		}
+		raw = strings.TrimSpace(string(data))
+	default:
+		return nil, fmt.Errorf("signedBy requirement has neither keyData nor keyPath")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("malformed key data: %w", err)
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// fingerprint renders a key's SHA-256 digest as a colon-separated hex
+// string, in the style of a GPG key fingerprint.
+func fingerprint(pubKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(pubKey)
+	hexStr := hex.EncodeToString(sum[:])
+
+	var groups []string
+	for i := 0; i < len(hexStr); i += 4 {
+		end := i + 4
+		if end > len(hexStr) {
+			end = len(hexStr)
+		}
+		groups = append(groups, hexStr[i:end])
+	}
+
+	return strings.ToUpper(strings.Join(groups, ":"))
+}
@@ -0,0 +1,90 @@
+package trust
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifySignedByRejectsGPGKeysType(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "source.json")
+	if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, pub := generateTestKey(t)
+	req := Requirement{
+		Type:    TypeSignedBy,
+		KeyType: KeyTypeGPGKeys,
+		KeyData: base64.StdEncoding.EncodeToString(pub),
+	}
+
+	if _, err := Verify(path, []Requirement{req}); err == nil {
+		t.Fatal("expected Verify to reject keyType GPGKeys, got nil error")
+	}
+}
+
+func TestVerifySignedByAcceptsEd25519PublicKey(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "source.json")
+	content := []byte(`{"hello":"world"}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	priv, pub := generateTestKey(t)
+	sig := ed25519.Sign(priv, content)
+	if err := os.WriteFile(path+".sig", []byte(base64.StdEncoding.EncodeToString(sig)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	req := Requirement{
+		Type:    TypeSignedBy,
+		KeyType: KeyTypeEd25519PublicKey,
+		KeyData: base64.StdEncoding.EncodeToString(pub),
+	}
+
+	fp, err := Verify(path, []Requirement{req})
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if fp == "" {
+		t.Error("expected a non-empty fingerprint for a verified signedBy source")
+	}
+}
+
+func TestVerifySignedByRejectsBadSignature(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "source.json")
+	if err := os.WriteFile(path, []byte("original"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	priv, pub := generateTestKey(t)
+	sig := ed25519.Sign(priv, []byte("different content"))
+	if err := os.WriteFile(path+".sig", []byte(base64.StdEncoding.EncodeToString(sig)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	req := Requirement{
+		Type:    TypeSignedBy,
+		KeyType: KeyTypeEd25519PublicKey,
+		KeyData: base64.StdEncoding.EncodeToString(pub),
+	}
+
+	if _, err := Verify(path, []Requirement{req}); err == nil {
+		t.Fatal("expected Verify to reject a signature over different content")
+	}
+}
+
+func generateTestKey(t *testing.T) (ed25519.PrivateKey, ed25519.PublicKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("failed to generate test key: %v", err)
+	}
+	return priv, pub
+}
@@ -2,15 +2,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/stig-processor/internal/agent"
 	"github.com/stig-processor/internal/processor"
+	"github.com/stig-processor/internal/server"
+	"github.com/stig-processor/pkg/generator"
 	"github.com/stig-processor/pkg/types"
 )
 
@@ -21,20 +26,79 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "eval":
+			if err := runEval(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "diff":
+			if err := runDiff(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "report":
+			if err := runReport(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "server":
+			if err := runServer(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "agent":
+			if err := runAgent(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	// Parse command line flags
 	var (
-		inputFile   = flag.String("input", types.DefaultInputFile, "Input STIG JSON file")
-		outputDir   = flag.String("output", types.DefaultOutputDir, "Output directory for Fleet policies")
-		format      = flag.String("format", types.DefaultOutputFormat, "Output format (yaml, json)")
-		severity    = flag.String("severity", "", "Filter by severity (low, medium, high)")
-		verbose     = flag.Bool("verbose", false, "Enable verbose logging")
-		dryRun      = flag.Bool("dry-run", false, "Dry run - don't write files")
-		pretty      = flag.Bool("pretty", false, "Pretty print JSON output")
-		timeout     = flag.Duration("timeout", types.DefaultTimeout, "Processing timeout")
-		showVersion = flag.Bool("version", false, "Show version information")
-		showStats   = flag.Bool("stats", false, "Show STIG statistics only")
-		validate    = flag.Bool("validate", false, "Validate existing policies only")
-		help        = flag.Bool("help", false, "Show help message")
+		inputFile              = flag.String("input", types.DefaultInputFile, "Input STIG JSON file")
+		inputFormat            = flag.String("input-format", "", "Input file format: xccdf, scap, cis-yaml, or stig-json (default: auto-detect from -input's extension)")
+		outputDir              = flag.String("output", types.DefaultOutputDir, "Output directory for Fleet policies")
+		format                 = flag.String("format", types.DefaultOutputFormat, "Output format (yaml, json, sarif, oscal, rego, rego-bundle)")
+		severity               = flag.String("severity", "", "Filter by severity (low, medium, high)")
+		verbose                = flag.Bool("verbose", false, "Enable verbose logging")
+		dryRun                 = flag.Bool("dry-run", false, "Dry run - don't write files")
+		pretty                 = flag.Bool("pretty", false, "Pretty print JSON output")
+		timeout                = flag.Duration("timeout", types.DefaultTimeout, "Processing timeout")
+		showVersion            = flag.Bool("version", false, "Show version information")
+		showStats              = flag.Bool("stats", false, "Show STIG statistics only")
+		validate               = flag.Bool("validate", false, "Validate existing policies only")
+		help                   = flag.Bool("help", false, "Show help message")
+		policiesDir            = flag.String("policies-dir", "", "Directory of .rego decision policies overriding default automatable/query/platform logic (see pkg/policyengine)")
+		capabilities           = flag.String("capabilities", "", "Capabilities file restricting which builtins -policies-dir policies may call")
+		stream                 = flag.Bool("stream", false, "Stream parse/generate/write instead of batching the whole benchmark in memory (incompatible with -policies-dir)")
+		workers                = flag.Int("workers", types.DefaultStreamWorkers, "Generator worker count when -stream is set")
+		enforcement            = flag.String("enforcement-actions", "", "Comma-separated severity=action pairs (audit, warn, deny, dryrun), e.g. high=deny,medium=warn,low=audit")
+		dryRunSeverities       = flag.String("dryrun-severities", "", "Comma-separated severities forced to dryrun regardless of -enforcement-actions")
+		filterCCI              = flag.String("filter-cci", "", "Comma-separated CCI list (see pkg/filters.FilterSpec); a rule must match one")
+		filterNIST             = flag.String("filter-nist-families", "", "Comma-separated NIST 800-53 control families (e.g. AC,CM); a rule's mapped controls must match one")
+		filterCIS              = flag.String("filter-cis-benchmarks", "", "Comma-separated CIS benchmark IDs; a rule must match one")
+		filterPlatform         = flag.String("filter-platform", "", "Platform a rule must resolve to (windows, linux, darwin)")
+		filterGroupIDs         = flag.String("filter-group-id-globs", "", "Comma-separated glob patterns matched against group ID")
+		filterConfig           = flag.String("filter-config", "", "Path to a pkg/filters.FilterSpec YAML file (overrides the other -filter-* flags)")
+		complianceMapping      = flag.String("compliance-mapping", "", "Path to a JSON file replacing the embedded default CCI-to-NIST/CIS mapping table")
+		concurrency            = flag.Int("concurrency", 0, "Worker goroutines classifying/generating policies in the batch pipeline (0 uses runtime.NumCPU(), ignored with -stream)")
+		writeConcurrency       = flag.Int("write-concurrency", 0, "Concurrent WritePolicy calls, bounded separately from -concurrency (0 matches -concurrency)")
+		failFast               = flag.Bool("fail-fast", false, "Stop starting new rules after the first ProcessingError; work already in flight still finishes")
+		strict                 = flag.Bool("strict", false, "Reject (instead of warn on) generated osquery SQL that violates -osquery-capabilities or fails dead-code checks")
+		osqueryCaps            = flag.String("osquery-capabilities", "", "Path to a JSON file restricting which osquery tables/columns generated SQL may reference (default: registry's path/data columns only). Distinct from -capabilities, which gates -policies-dir builtins")
+		bundle                 = flag.String("bundle", "", "Bundle layout to emit instead of a flat -output directory (fleet-gitops)")
+		team                   = flag.String("team", "", "Fleet team name for -bundle=fleet-gitops (default: \"default\")")
+		previousSTIG           = flag.String("previous-stig", "", "Path to an older STIG input file to diff against for CHANGELOG.md, written alongside a -bundle=fleet-gitops bundle. Distinct from the diff subcommand's -previous, which compares against this tool's own manifest")
+		enforcementProfile     = flag.String("enforcement-profile", "", "Named per-severity scoped enforcement mapping (default, strict) recorded as each policy's scoped_enforcement_actions, distinct from -enforcement-actions' single broadcast-to-every-point action")
+		enforcementProfileFile = flag.String("enforcement-profile-file", "", "Path to a JSON file of per-severity scoped enforcement action overrides layered on top of -enforcement-profile (or used alone)")
 	)
 
 	flag.Usage = func() {
@@ -48,6 +112,19 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s -stats -input stig.json\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -validate -output policies/\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -dry-run -verbose\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -stream -workers 8 -input full-quarterly-release.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -enforcement-actions high=deny,medium=warn,low=audit -dryrun-severities low\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -filter-nist-families AC,CM -filter-cis-benchmarks 5.1\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -concurrency 16 -write-concurrency 4 -fail-fast\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -strict -osquery-capabilities caps.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -input U_release_V1R1_STIG.zip -input-format scap\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -bundle fleet-gitops -team workstations -previous-stig stig-old.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -enforcement-profile strict -enforcement-profile-file cat-overrides.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s eval -input stig.json -policies-dir policies/\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s diff -input stig.json -output policies/\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s report -input stig.json -output policies/ -results results-in.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s server -addr :8090 -input stig.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s agent -server 127.0.0.1:8090\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "\n")
 	}
 
@@ -78,14 +155,38 @@ func main() {
 
 	// Create processing options
 	options := &types.ProcessingOptions{
-		InputFile: *inputFile,
-		OutputDir: *outputDir,
-		Format:    *format,
-		Severity:  *severity,
-		Verbose:   *verbose,
-		DryRun:    *dryRun,
-		Pretty:    *pretty,
-		Timeout:   *timeout,
+		InputFile:               *inputFile,
+		InputFormat:             *inputFormat,
+		OutputDir:               *outputDir,
+		Format:                  *format,
+		Severity:                *severity,
+		Verbose:                 *verbose,
+		DryRun:                  *dryRun,
+		Pretty:                  *pretty,
+		Timeout:                 *timeout,
+		PoliciesDir:             *policiesDir,
+		CapabilitiesFile:        *capabilities,
+		StreamOutput:            *stream,
+		Workers:                 *workers,
+		EnforcementActions:      parseEnforcementActions(*enforcement),
+		DryRunSeverities:        splitCommaList(*dryRunSeverities),
+		CCIFilter:               *filterCCI,
+		NISTFamilyFilter:        *filterNIST,
+		CISBenchmarkFilter:      *filterCIS,
+		PlatformFilter:          *filterPlatform,
+		GroupIDGlobFilter:       *filterGroupIDs,
+		FilterConfigFile:        *filterConfig,
+		ComplianceMappingFile:   *complianceMapping,
+		Concurrency:             *concurrency,
+		WriteConcurrency:        *writeConcurrency,
+		FailFast:                *failFast,
+		Strict:                  *strict,
+		OsqueryCapabilitiesFile: *osqueryCaps,
+		Bundle:                  *bundle,
+		Team:                    *team,
+		PreviousSTIGFile:        *previousSTIG,
+		EnforcementProfile:      *enforcementProfile,
+		EnforcementProfileFile:  *enforcementProfileFile,
 	}
 
 	// Create processor
@@ -112,6 +213,48 @@ func main() {
 	}
 }
 
+// parseEnforcementActions parses a comma-separated "severity=action" list
+// (as accepted by -enforcement-actions) into the map form
+// ProcessingOptions.EnforcementActions expects. Malformed entries
+// (missing "=", empty severity or action) are silently skipped.
+func parseEnforcementActions(raw string) map[string]string {
+	if raw == "" {
+		return nil
+	}
+
+	actions := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		severity := strings.ToLower(strings.TrimSpace(parts[0]))
+		action := strings.ToLower(strings.TrimSpace(parts[1]))
+		if severity == "" || action == "" {
+			continue
+		}
+		actions[severity] = action
+	}
+	return actions
+}
+
+// splitCommaList splits a comma-separated flag value into a trimmed,
+// non-empty string slice, as used by -dryrun-severities.
+func splitCommaList(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+
+	var out []string
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 // printVersion displays version information
 func printVersion() {
 	fmt.Printf("STIG Processor %s\n", version)
@@ -186,6 +329,277 @@ func runStatistics(proc *processor.STIGProcessor) error {
 	return nil
 }
 
+// runEval evaluates -policies-dir decision policies against a STIG file
+// without generating any Fleet policies, printing the resulting
+// RuleDecisions as JSON so they can be inspected before a real run.
+func runEval(args []string) error {
+	fs := flag.NewFlagSet("eval", flag.ExitOnError)
+	inputFile := fs.String("input", types.DefaultInputFile, "Input STIG JSON file")
+	severity := fs.String("severity", "", "Filter by severity (low, medium, high)")
+	verbose := fs.Bool("verbose", false, "Enable verbose logging")
+	timeout := fs.Duration("timeout", types.DefaultTimeout, "Processing timeout")
+	policiesDir := fs.String("policies-dir", "", "Directory of .rego decision policies (see pkg/policyengine)")
+	capabilities := fs.String("capabilities", "", "Capabilities file restricting which builtins -policies-dir policies may call")
+	pretty := fs.Bool("pretty", true, "Pretty print JSON output")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *policiesDir == "" {
+		return fmt.Errorf("-policies-dir is required for eval")
+	}
+
+	options := &types.ProcessingOptions{
+		InputFile:        *inputFile,
+		Severity:         *severity,
+		Verbose:          *verbose,
+		Timeout:          *timeout,
+		PoliciesDir:      *policiesDir,
+		CapabilitiesFile: *capabilities,
+	}
+
+	proc := processor.NewSTIGProcessor(options)
+
+	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
+	defer cancel()
+
+	decisions, err := proc.EvaluateRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if *pretty {
+		data, err = json.MarshalIndent(decisions, "", "  ")
+	} else {
+		data, err = json.Marshal(decisions)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal decisions: %w", err)
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
+
+// runDiff regenerates only the policies for rules that are new or
+// changed since the manifest at -previous was written, deletes the
+// policies of rules that disappeared, and prints the resulting
+// DiffResult as JSON so CI can gate review on only the affected files.
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	inputFile := fs.String("input", types.DefaultInputFile, "Input STIG JSON file")
+	outputDir := fs.String("output", types.DefaultOutputDir, "Output directory for Fleet policies")
+	format := fs.String("format", types.DefaultOutputFormat, "Output format (yaml, json, sarif, oscal, rego, rego-bundle)")
+	severity := fs.String("severity", "", "Filter by severity (low, medium, high)")
+	verbose := fs.Bool("verbose", false, "Enable verbose logging")
+	dryRun := fs.Bool("dry-run", false, "Dry run - don't write or delete files")
+	pretty := fs.Bool("pretty", false, "Pretty print JSON policy output")
+	timeout := fs.Duration("timeout", types.DefaultTimeout, "Processing timeout")
+	previous := fs.String("previous", "", "Path to the prior run's manifest (defaults to <output>/"+types.ManifestFilename+")")
+	jsonPretty := fs.Bool("json-pretty", true, "Pretty print the DiffResult JSON")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	options := &types.ProcessingOptions{
+		InputFile: *inputFile,
+		OutputDir: *outputDir,
+		Format:    *format,
+		Severity:  *severity,
+		Verbose:   *verbose,
+		DryRun:    *dryRun,
+		Pretty:    *pretty,
+		Timeout:   *timeout,
+	}
+
+	proc := processor.NewSTIGProcessor(options)
+
+	previousPath := *previous
+	if previousPath == "" {
+		previousPath = fmt.Sprintf("%s/%s", *outputDir, types.ManifestFilename)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
+	defer cancel()
+
+	result, err := proc.ProcessDiff(ctx, previousPath)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if *jsonPretty {
+		data, err = json.MarshalIndent(result, "", "  ")
+	} else {
+		data, err = json.Marshal(result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff result: %w", err)
+	}
+
+	fmt.Println(string(data))
+	return nil
+}
+
+// runReport generates Fleet policies exactly as the top-level processing
+// mode would, then attaches -results' externally observed pass/fail
+// outcomes to them as results.json and an aggregate
+// stig-evaluation.sarif.json in -output (see
+// STIGProcessor.WriteEvaluationReport).
+func runReport(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	inputFile := fs.String("input", types.DefaultInputFile, "Input STIG JSON file")
+	outputDir := fs.String("output", types.DefaultOutputDir, "Output directory for Fleet policies and the evaluation report")
+	format := fs.String("format", types.DefaultOutputFormat, "Output format for the generated Fleet policies (yaml, json, sarif, oscal, rego)")
+	severity := fs.String("severity", "", "Filter by severity (low, medium, high)")
+	verbose := fs.Bool("verbose", false, "Enable verbose logging")
+	dryRun := fs.Bool("dry-run", false, "Dry run - don't write Fleet policy files, only the evaluation report")
+	pretty := fs.Bool("pretty", true, "Pretty print results.json and the SARIF log")
+	timeout := fs.Duration("timeout", types.DefaultTimeout, "Processing timeout")
+	resultsFile := fs.String("results", "", "Path to a JSON array of {group_id, passed, message} evaluation outcomes (see pkg/generator.RuleResult)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *resultsFile == "" {
+		return fmt.Errorf("-results is required for report")
+	}
+
+	ruleResults, err := loadRuleResults(*resultsFile)
+	if err != nil {
+		return fmt.Errorf("failed to load -results: %w", err)
+	}
+
+	options := &types.ProcessingOptions{
+		InputFile: *inputFile,
+		OutputDir: *outputDir,
+		Format:    *format,
+		Severity:  *severity,
+		Verbose:   *verbose,
+		DryRun:    *dryRun,
+		Pretty:    *pretty,
+		Timeout:   *timeout,
+	}
+
+	proc := processor.NewSTIGProcessor(options)
+
+	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
+	defer cancel()
+
+	result, err := proc.ProcessWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := proc.WriteEvaluationReport(result, ruleResults, *outputDir); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote evaluation report for %d policies to %s\n", len(result.Policies), *outputDir)
+	return nil
+}
+
+// loadRuleResults decodes -results' JSON array of generator.RuleResult.
+func loadRuleResults(path string) ([]generator.RuleResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results file: %w", err)
+	}
+
+	var results []generator.RuleResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse results file: %w", err)
+	}
+	return results, nil
+}
+
+// runServer starts the "stig-processor server" control node: a JSON-RPC
+// 2.0 endpoint that hands every connecting agent the same Process job,
+// built from this command's flags, then prints the progress and result
+// it streams back.
+func runServer(args []string) error {
+	fs := flag.NewFlagSet("server", flag.ExitOnError)
+	addr := fs.String("addr", ":8090", "Address to listen on for agent connections")
+	inputFile := fs.String("input", types.DefaultInputFile, "Input STIG JSON file (resolved on the agent's filesystem)")
+	outputDir := fs.String("output", types.DefaultOutputDir, "Output directory for Fleet policies (resolved on the agent's filesystem)")
+	format := fs.String("format", types.DefaultOutputFormat, "Output format (yaml, json, sarif, oscal, rego, rego-bundle)")
+	severity := fs.String("severity", "", "Filter by severity (low, medium, high)")
+	dryRun := fs.Bool("dry-run", false, "Dry run - don't write files")
+	pretty := fs.Bool("pretty", false, "Pretty print JSON output")
+	policiesDir := fs.String("policies-dir", "", "Directory of .rego decision policies (see pkg/policyengine)")
+	capabilities := fs.String("capabilities", "", "Capabilities file restricting which builtins -policies-dir policies may call")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		fmt.Fprintln(os.Stderr, "\nReceived interrupt signal, shutting down...")
+		cancel()
+	}()
+
+	return server.Run(ctx, server.Options{
+		Addr: *addr,
+		Job: server.Job{
+			InputFile:        *inputFile,
+			OutputDir:        *outputDir,
+			Format:           *format,
+			Severity:         *severity,
+			DryRun:           *dryRun,
+			Pretty:           *pretty,
+			PoliciesDir:      *policiesDir,
+			CapabilitiesFile: *capabilities,
+		},
+	})
+}
+
+// runAgent starts the "stig-processor agent" subcommand: it dials out to
+// a control-node server and serves whatever Process/GetStatistics/
+// ValidatePolicies/Cancel calls the server sends back.
+func runAgent(args []string) error {
+	fs := flag.NewFlagSet("agent", flag.ExitOnError)
+	serverAddr := fs.String("server", "", "Control-node address to connect to (host:port)")
+	retryLimit := fs.Int("retry-limit", 0, "Maximum connect attempts with exponential backoff (0 = retry forever)")
+	maxProcs := fs.Int("max-procs", 1, "Maximum concurrent STIG processing jobs this agent runs (0 = unbounded)")
+	verbose := fs.Bool("verbose", false, "Enable verbose logging")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *serverAddr == "" {
+		return fmt.Errorf("-server is required for agent")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		fmt.Fprintln(os.Stderr, "\nReceived interrupt signal, shutting down...")
+		cancel()
+	}()
+
+	return agent.Run(ctx, agent.Options{
+		ServerAddr: *serverAddr,
+		RetryLimit: *retryLimit,
+		MaxProcs:   *maxProcs,
+		Verbose:    *verbose,
+	})
+}
+
 // printProcessingResults displays the processing results in a formatted way
 func printProcessingResults(result *types.ProcessingResult, options *types.ProcessingOptions, duration time.Duration) {
 	fmt.Printf("✅ STIG Processing Complete!\n\n")
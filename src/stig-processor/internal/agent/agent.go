@@ -0,0 +1,209 @@
+// Package agent implements the "stig-processor agent" subcommand: it
+// dials out to a control-node server and then serves the Process,
+// GetStatistics, ValidatePolicies, and Cancel JSON-RPC 2.0 calls the
+// server sends back over that connection, running each against a local
+// STIGProcessor and streaming per-rule progress back as notifications.
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/stig-processor/internal/processor"
+	"github.com/stig-processor/pkg/rpc"
+	"github.com/stig-processor/pkg/types"
+)
+
+// ProcessParams is what the server sends in a "Process" call: the local
+// STIG file for this agent to parse (agents typically each hold a
+// different platform's XCCDF bundle) plus the processing options to
+// apply.
+type ProcessParams struct {
+	InputFile        string `json:"inputFile"`
+	OutputDir        string `json:"outputDir"`
+	Format           string `json:"format"`
+	Severity         string `json:"severity"`
+	DryRun           bool   `json:"dryRun"`
+	Pretty           bool   `json:"pretty"`
+	PoliciesDir      string `json:"policiesDir,omitempty"`
+	CapabilitiesFile string `json:"capabilitiesFile,omitempty"`
+}
+
+// StatisticsParams is what the server sends in a "GetStatistics" call.
+type StatisticsParams struct {
+	InputFile string `json:"inputFile"`
+}
+
+// ValidateParams is what the server sends in a "ValidatePolicies" call.
+type ValidateParams struct {
+	OutputDir string `json:"outputDir"`
+}
+
+// CancelParams names the in-flight job (by InputFile) a "Cancel" call
+// should stop.
+type CancelParams struct {
+	JobID string `json:"jobId"`
+}
+
+// Options configures Run.
+type Options struct {
+	ServerAddr string
+	RetryLimit int
+	MaxProcs   int
+	Verbose    bool
+}
+
+// Run dials opts.ServerAddr with exponential backoff (bounded by
+// opts.RetryLimit attempts; <= 0 retries forever), then serves incoming
+// RPC calls until the connection closes or ctx is cancelled.
+func Run(ctx context.Context, opts Options) error {
+	conn, err := rpc.DialWithBackoff(ctx, opts.RetryLimit, func() (*rpc.Conn, error) {
+		c, err := net.Dial("tcp", opts.ServerAddr)
+		if err != nil {
+			return nil, err
+		}
+		return rpc.NewConn(c), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", opts.ServerAddr, err)
+	}
+
+	// Dispatcher.Serve only notices ctx cancellation between requests, so
+	// an agent idling in Receive needs its connection closed out from
+	// under it to actually shut down promptly.
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	sem := newSemaphore(opts.MaxProcs)
+
+	var cancelMu sync.Mutex
+	cancels := make(map[string]context.CancelFunc)
+
+	dispatcher := rpc.NewDispatcher()
+
+	dispatcher.Handle("Process", func(params json.RawMessage) (any, error) {
+		var p ProcessParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid Process params: %w", err)
+		}
+
+		sem.acquire()
+		defer sem.release()
+
+		jobCtx, cancel := context.WithCancel(ctx)
+		cancelMu.Lock()
+		cancels[p.InputFile] = cancel
+		cancelMu.Unlock()
+		defer func() {
+			cancelMu.Lock()
+			delete(cancels, p.InputFile)
+			cancelMu.Unlock()
+			cancel()
+		}()
+
+		options := &types.ProcessingOptions{
+			InputFile:        p.InputFile,
+			OutputDir:        p.OutputDir,
+			Format:           p.Format,
+			Severity:         p.Severity,
+			Verbose:          opts.Verbose,
+			DryRun:           p.DryRun,
+			Pretty:           p.Pretty,
+			PoliciesDir:      p.PoliciesDir,
+			CapabilitiesFile: p.CapabilitiesFile,
+			MaxProcs:         opts.MaxProcs,
+		}
+
+		sp := processor.NewSTIGProcessor(options)
+
+		events := make(chan types.ProcessingEvent, 16)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for event := range events {
+				req, err := rpc.NewRequest("", "progress", event)
+				if err != nil {
+					continue
+				}
+				_ = conn.Send(req)
+			}
+		}()
+
+		result, err := sp.ProcessStreaming(jobCtx, events)
+		close(events)
+		<-done
+
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+
+	dispatcher.Handle("GetStatistics", func(params json.RawMessage) (any, error) {
+		var p StatisticsParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid GetStatistics params: %w", err)
+		}
+
+		sp := processor.NewSTIGProcessor(&types.ProcessingOptions{InputFile: p.InputFile, Verbose: opts.Verbose})
+		return sp.GetStatistics()
+	})
+
+	dispatcher.Handle("ValidatePolicies", func(params json.RawMessage) (any, error) {
+		var p ValidateParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid ValidatePolicies params: %w", err)
+		}
+
+		sp := processor.NewSTIGProcessor(&types.ProcessingOptions{OutputDir: p.OutputDir, Verbose: opts.Verbose})
+		return sp.ValidatePolicies()
+	})
+
+	dispatcher.Handle("Cancel", func(params json.RawMessage) (any, error) {
+		var p CancelParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid Cancel params: %w", err)
+		}
+
+		cancelMu.Lock()
+		cancel, ok := cancels[p.JobID]
+		cancelMu.Unlock()
+
+		if !ok {
+			return nil, fmt.Errorf("no in-flight job for %q", p.JobID)
+		}
+		cancel()
+		return map[string]bool{"cancelled": true}, nil
+	})
+
+	return dispatcher.Serve(ctx, conn)
+}
+
+// semaphore bounds concurrent Process jobs. A nil-capacity semaphore
+// (maxProcs <= 0) never blocks, matching ProcessingOptions.MaxProcs'
+// "0 means unbounded" contract.
+type semaphore chan struct{}
+
+func newSemaphore(maxProcs int) semaphore {
+	if maxProcs <= 0 {
+		return nil
+	}
+	return make(semaphore, maxProcs)
+}
+
+func (s semaphore) acquire() {
+	if s != nil {
+		s <- struct{}{}
+	}
+}
+
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
@@ -0,0 +1,186 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stig-processor/pkg/rpc"
+	"github.com/stig-processor/pkg/types"
+)
+
+func TestSemaphoreBoundsConcurrency(t *testing.T) {
+	sem := newSemaphore(2)
+
+	sem.acquire()
+	sem.acquire()
+
+	acquired := make(chan struct{})
+	go func() {
+		sem.acquire()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("expected a third acquire to block while the semaphore is at capacity")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	sem.release()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("expected the blocked acquire to unblock after release")
+	}
+}
+
+func TestSemaphoreUnboundedWhenMaxProcsNonPositive(t *testing.T) {
+	for _, maxProcs := range []int{0, -1} {
+		sem := newSemaphore(maxProcs)
+		if sem != nil {
+			t.Fatalf("newSemaphore(%d): got non-nil semaphore, want nil (unbounded)", maxProcs)
+		}
+		// Must never block regardless of how many times it's acquired.
+		sem.acquire()
+		sem.acquire()
+		sem.acquire()
+		sem.release()
+		sem.release()
+		sem.release()
+	}
+}
+
+// writeMinimalSTIGFixture writes a one-group stig-json benchmark that
+// internal/processor can parse, generate policies for, and analyze
+// statistics on without any PoliciesDir/ComplianceMappingFile wiring.
+func writeMinimalSTIGFixture(t *testing.T, dir string) string {
+	t.Helper()
+	benchmark := types.STIGBenchmark{
+		BenchmarkID: "TEST-STIG",
+		Title:       "Test Benchmark",
+		Version:     "1",
+		Groups: []types.STIGGroup{
+			{
+				GroupID:      "V-1",
+				RuleVersion:  "TEST-000001",
+				RuleTitle:    "A rule",
+				RuleSeverity: "medium",
+			},
+		},
+	}
+	data, err := json.Marshal(benchmark)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "benchmark.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestRunServesProcessStatisticsAndValidateOverRealConnection dials a
+// real TCP agent (Run) from a hand-rolled server speaking the same
+// JSON-RPC 2.0 protocol, exercising GetStatistics, Process, Cancel, and
+// ValidatePolicies exactly as internal/server drives a live agent.
+func TestRunServesProcessStatisticsAndValidateOverRealConnection(t *testing.T) {
+	dir := t.TempDir()
+	inputFile := writeMinimalSTIGFixture(t, dir)
+	outputDir := filepath.Join(dir, "out")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- Run(ctx, Options{ServerAddr: listener.Addr().String(), RetryLimit: 1})
+	}()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("failed to accept agent connection: %v", err)
+	}
+	defer conn.Close()
+
+	client := rpc.NewClient(rpc.NewConn(conn))
+	go client.Listen()
+
+	statsResp, err := client.Call("GetStatistics", StatisticsParams{InputFile: inputFile})
+	if err != nil {
+		t.Fatalf("GetStatistics call failed: %v", err)
+	}
+	if statsResp.Error != nil {
+		t.Fatalf("GetStatistics failed: %s", statsResp.Error.Message)
+	}
+	var stats types.ProcessingStatistics
+	if err := json.Unmarshal(statsResp.Result, &stats); err != nil {
+		t.Fatalf("failed to decode statistics: %v", err)
+	}
+	if stats.TotalRules != 1 {
+		t.Errorf("got TotalRules %d, want 1", stats.TotalRules)
+	}
+
+	processResp, err := client.Call("Process", ProcessParams{InputFile: inputFile, OutputDir: outputDir, Format: "json"})
+	if err != nil {
+		t.Fatalf("Process call failed: %v", err)
+	}
+	if processResp.Error != nil {
+		t.Fatalf("Process failed: %s", processResp.Error.Message)
+	}
+	var result types.ProcessingResult
+	if err := json.Unmarshal(processResp.Result, &result); err != nil {
+		t.Fatalf("failed to decode process result: %v", err)
+	}
+	if result.Total != 1 {
+		t.Errorf("got Total %d, want 1", result.Total)
+	}
+
+	// Process has already returned, so its cancel-bookkeeping entry has
+	// been cleaned up: Cancel against the same InputFile should now
+	// report no in-flight job.
+	cancelResp, err := client.Call("Cancel", CancelParams{JobID: inputFile})
+	if err != nil {
+		t.Fatalf("Cancel call failed: %v", err)
+	}
+	if cancelResp.Error == nil {
+		t.Error("expected Cancel to fail once the job has already completed and been removed from bookkeeping")
+	}
+
+	validateResp, err := client.Call("ValidatePolicies", ValidateParams{OutputDir: outputDir})
+	if err != nil {
+		t.Fatalf("ValidatePolicies call failed: %v", err)
+	}
+	if validateResp.Error != nil {
+		t.Fatalf("ValidatePolicies failed: %s", validateResp.Error.Message)
+	}
+	var validation types.ValidationResult
+	if err := json.Unmarshal(validateResp.Result, &validation); err != nil {
+		t.Fatalf("failed to decode validation result: %v", err)
+	}
+	if !validation.Valid {
+		t.Errorf("got validation.Valid = false, errors: %v", validation.Errors)
+	}
+
+	cancel()
+	select {
+	case err := <-runErr:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got Run() = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for Run to return after ctx cancellation")
+	}
+}
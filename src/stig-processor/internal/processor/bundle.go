@@ -0,0 +1,87 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stig-processor/pkg/generator"
+	"github.com/stig-processor/pkg/types"
+)
+
+// processFleetGitOpsBundle is the ProcessWithContext branch used when
+// options.Bundle is types.BundleFleetGitOps: it runs the normal Fleet
+// policy pipeline in memory, same decision-policy evaluation and
+// compliance configuration as the default path, except with DryRun
+// forced on so generatePolicies doesn't also write its own flat
+// WritePolicy/WriteSummary files - then hands the resulting policies to
+// a generator.BundleWriter to lay out as a Fleet GitOps repo under
+// options.OutputDir instead. When options.PreviousSTIGFile is set, it
+// also diffs that older benchmark against the one just parsed and writes
+// CHANGELOG.md alongside the bundle.
+func (sp *STIGProcessor) processFleetGitOpsBundle(ctx context.Context) (*types.ProcessingResult, error) {
+	start := time.Now()
+
+	stig, err := sp.parseSTIGFile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse STIG file: %w", err)
+	}
+	groups := sp.filterGroups(stig.Groups)
+
+	var policyErrors []types.ProcessingError
+	if sp.options.PoliciesDir != "" {
+		decisions, compileErrors, err := sp.evaluatePolicies(ctx, groups)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate decision policies: %w", err)
+		}
+		sp.generator.SetDecisions(decisions)
+		policyErrors = compileErrors
+	}
+
+	if err := sp.configureCompliance(); err != nil {
+		return nil, fmt.Errorf("failed to configure compliance filter: %w", err)
+	}
+
+	wantDryRun := sp.options.DryRun
+	sp.options.DryRun = true
+	result := sp.generatePolicies(ctx, groups)
+	sp.options.DryRun = wantDryRun
+	result.Errors = append(result.Errors, policyErrors...)
+
+	if !sp.options.DryRun {
+		var writer generator.BundleWriter
+		if err := writer.WriteBundle(result.Policies, sp.options.OutputDir, sp.options.Team); err != nil {
+			result.Errors = append(result.Errors, types.ProcessingError{
+				Message:   fmt.Sprintf("failed to write fleet-gitops bundle: %v", err),
+				Type:      types.ErrorTypeFileWriteFailed,
+				Timestamp: time.Now(),
+			})
+		}
+
+		if sp.options.PreviousSTIGFile != "" {
+			if err := sp.writeBundleChangelog(stig); err != nil {
+				result.Errors = append(result.Errors, types.ProcessingError{
+					Message:   fmt.Sprintf("failed to write changelog: %v", err),
+					Type:      types.ErrorTypeFileWriteFailed,
+					Timestamp: time.Now(),
+				})
+			}
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// writeBundleChangelog parses options.PreviousSTIGFile with the same
+// LoadBenchmark entry point used for the main input, diffs it against
+// current, and writes CHANGELOG.md alongside the bundle.
+func (sp *STIGProcessor) writeBundleChangelog(current *types.STIGBenchmark) error {
+	previous, err := sp.parser.LoadBenchmark(sp.options.PreviousSTIGFile, sp.options.InputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to parse previous STIG file %s: %w", sp.options.PreviousSTIGFile, err)
+	}
+
+	diff := generator.DiffBenchmarks(previous, current)
+	return generator.WriteChangelog(diff, previous.Version, current.Version, sp.options.OutputDir)
+}
@@ -0,0 +1,202 @@
+package processor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/stig-processor/pkg/generator"
+	"github.com/stig-processor/pkg/types"
+)
+
+// fingerprintGroup hashes the parts of a STIGGroup that determine what
+// ProcessDiff generates for it: the check content, the severity (which
+// can change which policies get filtered out), and the fix text. Other
+// fields (title wording, CCI, vulnerability discussion) are not
+// fingerprinted, so a change to only those leaves the rule Unchanged and
+// its policy file is not rewritten.
+func fingerprintGroup(group *types.STIGGroup) string {
+	h := sha256.New()
+	h.Write([]byte(group.RuleCheckContent))
+	h.Write([]byte(group.RuleSeverity))
+	h.Write([]byte(group.RuleFixText))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// manifestPath returns the path of the fingerprint manifest ProcessDiff
+// persists in the configured OutputDir.
+func (sp *STIGProcessor) manifestPath() string {
+	return filepath.Join(sp.options.OutputDir, types.ManifestFilename)
+}
+
+// loadManifest reads the manifest at path. A missing file is not an
+// error - it's treated as an empty manifest, so the first ever diff run
+// against a directory classifies every rule as Added.
+func loadManifest(path string) (*types.Manifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &types.Manifest{Rules: make(map[string]types.RuleFingerprint)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+
+	var manifest types.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if manifest.Rules == nil {
+		manifest.Rules = make(map[string]types.RuleFingerprint)
+	}
+	return &manifest, nil
+}
+
+// writeManifest persists manifest as indented JSON at path.
+func writeManifest(path string, manifest *types.Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeDiffPolicy writes policy to OutputDir the same way the batch
+// pipeline does, respecting DryRun.
+func (sp *STIGProcessor) writeDiffPolicy(policy *types.FleetPolicy) error {
+	if sp.options.DryRun {
+		return nil
+	}
+	return sp.generator.WritePolicy(policy, sp.options.OutputDir)
+}
+
+// ProcessDiff parses and classifies the configured input file's rules
+// against the fingerprint manifest left behind by a previous ProcessDiff
+// run at previousResultPath, regenerating only the policy files for
+// rules that are new or whose check/severity/fix content changed, and
+// deleting the policy files of rules that disappeared. Unchanged rules
+// are left untouched on disk. Unless DryRun is set, a fresh manifest
+// reflecting this run is written to OutputDir/ManifestFilename
+// afterward, whether or not a previous one was found.
+func (sp *STIGProcessor) ProcessDiff(ctx context.Context, previousResultPath string) (*types.DiffResult, error) {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, sp.options.Timeout)
+	defer cancel()
+
+	if err := sp.validateInputs(); err != nil {
+		return nil, fmt.Errorf("input validation failed: %w", err)
+	}
+
+	previous, err := loadManifest(previousResultPath)
+	if err != nil {
+		return nil, err
+	}
+
+	stig, err := sp.parseSTIGFile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse STIG file: %w", err)
+	}
+	groups := sp.filterGroups(stig.Groups)
+
+	diff := &types.DiffResult{Rules: make([]types.DiffRule, 0, len(groups))}
+	current := &types.Manifest{Rules: make(map[string]types.RuleFingerprint, len(groups))}
+	seen := make(map[string]bool, len(groups))
+
+	for _, group := range groups {
+		select {
+		case <-ctx.Done():
+			return diff, ctx.Err()
+		default:
+		}
+
+		fingerprint := fingerprintGroup(&group)
+		seen[group.RuleID] = true
+
+		prior, existed := previous.Rules[group.RuleID]
+
+		policy, automatable, err := sp.generator.ClassifyGroup(&group)
+		if !automatable {
+			continue
+		}
+		if err != nil {
+			return diff, fmt.Errorf("failed to generate policy for rule %s: %w", group.RuleID, err)
+		}
+
+		policyFile := generator.PolicyFilename(policy, sp.options.Format)
+
+		var status types.DiffStatus
+		switch {
+		case !existed:
+			status = types.DiffStatusAdded
+		case prior.Fingerprint != fingerprint:
+			status = types.DiffStatusModified
+		default:
+			status = types.DiffStatusUnchanged
+		}
+
+		current.Rules[group.RuleID] = types.RuleFingerprint{
+			GroupID:     group.GroupID,
+			RuleID:      group.RuleID,
+			PolicyFile:  policyFile,
+			Fingerprint: fingerprint,
+		}
+		diff.Rules = append(diff.Rules, types.DiffRule{
+			GroupID:    group.GroupID,
+			RuleID:     group.RuleID,
+			PolicyFile: policyFile,
+			Status:     status,
+		})
+
+		switch status {
+		case types.DiffStatusUnchanged:
+			diff.UnchangedFiles = append(diff.UnchangedFiles, policyFile)
+		default:
+			if err := sp.writeDiffPolicy(policy); err != nil {
+				return diff, fmt.Errorf("failed to write policy for rule %s: %w", group.RuleID, err)
+			}
+			diff.RewrittenFiles = append(diff.RewrittenFiles, policyFile)
+		}
+	}
+
+	// Anything in the previous manifest not seen in this run was removed.
+	for ruleID, prior := range previous.Rules {
+		if seen[ruleID] {
+			continue
+		}
+		diff.Rules = append(diff.Rules, types.DiffRule{
+			GroupID:    prior.GroupID,
+			RuleID:     ruleID,
+			PolicyFile: prior.PolicyFile,
+			Status:     types.DiffStatusRemoved,
+		})
+		if !sp.options.DryRun {
+			path := filepath.Join(sp.options.OutputDir, prior.PolicyFile)
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return diff, fmt.Errorf("failed to delete policy file %s: %w", path, err)
+			}
+		}
+		diff.DeletedFiles = append(diff.DeletedFiles, prior.PolicyFile)
+	}
+
+	sort.Strings(diff.RewrittenFiles)
+	sort.Strings(diff.UnchangedFiles)
+	sort.Strings(diff.DeletedFiles)
+
+	if !sp.options.DryRun {
+		if err := writeManifest(sp.manifestPath(), current); err != nil {
+			return diff, err
+		}
+	}
+
+	diff.Duration = time.Since(start)
+	return diff, nil
+}
@@ -0,0 +1,194 @@
+package processor
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stig-processor/pkg/generator"
+	"github.com/stig-processor/pkg/types"
+)
+
+// Marshaler renders a FleetPolicy into one format's on-disk bytes.
+type Marshaler func(policy *types.FleetPolicy, pretty bool) ([]byte, error)
+
+// Unmarshaler decodes a format's on-disk bytes into whatever
+// intermediate value that format's Validator expects: a *types.FleetPolicy
+// for yaml/json, or a format-specific shape for anything richer.
+type Unmarshaler func(data []byte) (any, error)
+
+// Validator checks a value an Unmarshaler produced, beyond what decoding
+// already guarantees.
+type Validator func(v any) error
+
+// Format is one entry in the registry validateInputs and
+// validatePolicyFiles consult.
+type Format struct {
+	// Extensions are the filename suffixes validatePolicyFiles matches
+	// against, checked longest-first so e.g. ".sarif.json" wins over the
+	// plainer ".json".
+	Extensions []string
+	// ErrorType tags a types.ValidationError raised by this format's
+	// Unmarshal/Validate.
+	ErrorType types.ValidationErrorType
+	Marshal   Marshaler
+	Unmarshal Unmarshaler
+	Validate  Validator
+}
+
+var formatRegistry = make(map[string]Format)
+
+// RegisterFormat installs impl as the format named name, overwriting any
+// previous registration for that name. Third parties add an output
+// format stig-processor doesn't ship (e.g. a -format cyclonedx flag) by
+// calling this from an init() func before NewSTIGProcessor runs.
+func RegisterFormat(name string, impl Format) {
+	formatRegistry[name] = impl
+}
+
+// RegisteredFormats returns the names of all registered formats, sorted,
+// for use in flag help text and error messages.
+func RegisteredFormats() []string {
+	names := make([]string, 0, len(formatRegistry))
+	for name := range formatRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatForFile returns the registered Format whose Extensions best
+// match filename (the longest matching extension wins), and false if no
+// registered Format claims it.
+func formatForFile(filename string) (Format, bool) {
+	var best Format
+	var bestLen int
+	found := false
+	for _, f := range formatRegistry {
+		for _, ext := range f.Extensions {
+			if strings.HasSuffix(filename, ext) && len(ext) > bestLen {
+				best, bestLen, found = f, len(ext), true
+			}
+		}
+	}
+	return best, found
+}
+
+// sarifValidationShape and oscalValidationShape are deliberately minimal:
+// just enough of each format's JSON shape for validatePolicyFiles to
+// confirm a generated file round-trips, not full schema structs. The
+// canonical shapes live alongside generator.MarshalSARIF/MarshalOSCAL.
+type sarifValidationShape struct {
+	Version string            `json:"version"`
+	Runs    []json.RawMessage `json:"runs"`
+}
+
+type oscalValidationShape struct {
+	ComponentDefinition struct {
+		UUID       string            `json:"uuid"`
+		Components []json.RawMessage `json:"components"`
+	} `json:"component-definition"`
+}
+
+func init() {
+	RegisterFormat("yaml", Format{
+		Extensions: []string{".yaml", ".yml"},
+		ErrorType:  types.ValidationErrorYAMLSyntax,
+		Marshal: func(policy *types.FleetPolicy, pretty bool) ([]byte, error) {
+			return yaml.Marshal(policy)
+		},
+		Unmarshal: func(data []byte) (any, error) {
+			var policy types.FleetPolicy
+			if err := yaml.Unmarshal(data, &policy); err != nil {
+				return nil, fmt.Errorf("invalid YAML syntax: %w", err)
+			}
+			return &policy, nil
+		},
+		Validate: func(v any) error { return validateFleetPolicyStructure(v.(*types.FleetPolicy)) },
+	})
+
+	RegisterFormat("json", Format{
+		Extensions: []string{".json"},
+		ErrorType:  types.ValidationErrorJSONSyntax,
+		Marshal: func(policy *types.FleetPolicy, pretty bool) ([]byte, error) {
+			if pretty {
+				return json.MarshalIndent(policy, "", "  ")
+			}
+			return json.Marshal(policy)
+		},
+		Unmarshal: func(data []byte) (any, error) {
+			var policy types.FleetPolicy
+			if err := json.Unmarshal(data, &policy); err != nil {
+				return nil, fmt.Errorf("invalid JSON syntax: %w", err)
+			}
+			return &policy, nil
+		},
+		Validate: func(v any) error { return validateFleetPolicyStructure(v.(*types.FleetPolicy)) },
+	})
+
+	RegisterFormat("sarif", Format{
+		Extensions: []string{".sarif.json"},
+		ErrorType:  types.ValidationErrorSARIFSchema,
+		Marshal:    generator.MarshalSARIF,
+		Unmarshal: func(data []byte) (any, error) {
+			var log sarifValidationShape
+			if err := json.Unmarshal(data, &log); err != nil {
+				return nil, fmt.Errorf("invalid SARIF JSON: %w", err)
+			}
+			return &log, nil
+		},
+		Validate: func(v any) error {
+			log := v.(*sarifValidationShape)
+			if log.Version != "2.1.0" {
+				return fmt.Errorf("unsupported SARIF version: %q", log.Version)
+			}
+			if len(log.Runs) == 0 {
+				return fmt.Errorf("SARIF log has no runs")
+			}
+			return nil
+		},
+	})
+
+	RegisterFormat("oscal", Format{
+		Extensions: []string{".oscal.json"},
+		ErrorType:  types.ValidationErrorOSCALSchema,
+		Marshal:    generator.MarshalOSCAL,
+		Unmarshal: func(data []byte) (any, error) {
+			var doc oscalValidationShape
+			if err := json.Unmarshal(data, &doc); err != nil {
+				return nil, fmt.Errorf("invalid OSCAL JSON: %w", err)
+			}
+			return &doc, nil
+		},
+		Validate: func(v any) error {
+			doc := v.(*oscalValidationShape)
+			if doc.ComponentDefinition.UUID == "" {
+				return fmt.Errorf("component-definition.uuid is required")
+			}
+			if len(doc.ComponentDefinition.Components) == 0 {
+				return fmt.Errorf("component-definition has no components")
+			}
+			return nil
+		},
+	})
+
+	RegisterFormat("rego", Format{
+		Extensions: []string{".rego"},
+		ErrorType:  types.ValidationErrorRegoSyntax,
+		Marshal: func(policy *types.FleetPolicy, pretty bool) ([]byte, error) {
+			return []byte(policy.Spec.Rego), nil
+		},
+		Unmarshal: func(data []byte) (any, error) {
+			return string(data), nil
+		},
+		Validate: func(v any) error {
+			if !strings.HasPrefix(strings.TrimSpace(v.(string)), "package ") {
+				return fmt.Errorf("rego policy must start with a package declaration")
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,170 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// processStreamPipeline implements the streaming pipeline
+// ProcessWithContext uses when options.StreamOutput is set, in place of
+// the batch parse-everything/generate-everything/write-everything
+// pipeline: pkg/parser.ParseSTIGFileStreaming emits STIGGroups onto a
+// channel as it decodes them, a filter stage drops groups that don't
+// match options.Severity, a pool of options.Workers generator goroutines
+// turn surviving groups into FleetPolicies, and a single writer goroutine
+// marshals/writes each one as it arrives. At no point does this method
+// hold more than a handful of groups/policies in memory at once,
+// regardless of how large the benchmark is - unlike the batch pipeline,
+// the returned ProcessingResult's Policies field is left empty. Each
+// generated policy's osquery SQL is also checked against
+// options.Strict/the configured osquery capabilities, same as the batch
+// pipeline's generateOneWithRecover: a violation is either a fatal
+// ProcessingError (Strict) or a ProcessingResult.Warnings entry.
+func (sp *STIGProcessor) processStreamPipeline(ctx context.Context) (*types.ProcessingResult, error) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, sp.options.Timeout)
+	defer cancel()
+
+	workers := sp.options.Workers
+	if workers <= 0 {
+		workers = types.DefaultStreamWorkers
+	}
+
+	groupsCh := make(chan types.STIGGroup, workers)
+	filteredCh := make(chan types.STIGGroup, workers)
+	policiesCh := make(chan *types.FleetPolicy, workers)
+
+	result := &types.ProcessingResult{Errors: make([]types.ProcessingError, 0)}
+	var mu sync.Mutex
+	recordError := func(groupID, ruleID, message string, errType types.ErrorType) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.Errors = append(result.Errors, types.ProcessingError{
+			GroupID:   groupID,
+			RuleID:    ruleID,
+			Message:   message,
+			Type:      errType,
+			Timestamp: time.Now(),
+		})
+	}
+	recordWarning := func(groupID, ruleID, message string) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.Warnings = append(result.Warnings, types.ProcessingError{
+			GroupID:   groupID,
+			RuleID:    ruleID,
+			Message:   message,
+			Type:      types.ErrorTypeCapabilityViolation,
+			Timestamp: time.Now(),
+		})
+	}
+
+	// Parse stage: streams groups in, one at a time, off the file.
+	var parseErr error
+	go func() {
+		defer close(groupsCh)
+		_, err := sp.parser.ParseSTIGFileStreaming(ctx, sp.options.InputFile, groupsCh)
+		if err != nil {
+			mu.Lock()
+			parseErr = err
+			mu.Unlock()
+		}
+	}()
+
+	// Filter stage: drops groups that don't match options.Severity.
+	go func() {
+		defer close(filteredCh)
+		for group := range groupsCh {
+			if sp.options.Severity != "" && !strings.EqualFold(group.RuleSeverity, sp.options.Severity) {
+				continue
+			}
+			mu.Lock()
+			result.Total++
+			mu.Unlock()
+			select {
+			case filteredCh <- group:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Generator stage: workers goroutines classify+generate concurrently.
+	var genWG sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		genWG.Add(1)
+		go func() {
+			defer genWG.Done()
+			for group := range filteredCh {
+				policy, automatable, err := sp.generator.ClassifyGroup(&group)
+				if !automatable {
+					mu.Lock()
+					result.ManualReview++
+					mu.Unlock()
+					continue
+				}
+
+				mu.Lock()
+				result.Automatable++
+				mu.Unlock()
+
+				if err != nil {
+					recordError(group.GroupID, group.RuleID, err.Error(), types.ErrorTypeValidationFailed)
+					continue
+				}
+
+				if issues := sp.generator.CheckOsqueryCapabilities(policy); len(issues) > 0 {
+					message := strings.Join(issues, "; ")
+					if sp.options.Strict {
+						recordError(group.GroupID, group.RuleID, "osquery capability violations: "+message, types.ErrorTypeCapabilityViolation)
+						continue
+					}
+					recordWarning(group.GroupID, group.RuleID, message)
+				}
+
+				select {
+				case policiesCh <- policy:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		genWG.Wait()
+		close(policiesCh)
+	}()
+
+	// Writer stage: a single goroutine so policy files are written one
+	// at a time even though workers generates them concurrently.
+	var writeWG sync.WaitGroup
+	writeWG.Add(1)
+	go func() {
+		defer writeWG.Done()
+		for policy := range policiesCh {
+			if sp.options.DryRun {
+				continue
+			}
+			if err := sp.generator.WritePolicy(policy, sp.options.OutputDir); err != nil {
+				recordError("", "", fmt.Sprintf("failed to write policy: %v", err), types.ErrorTypeFileWriteFailed)
+			}
+		}
+	}()
+
+	writeWG.Wait()
+
+	mu.Lock()
+	err := parseErr
+	mu.Unlock()
+	if err != nil {
+		return result, fmt.Errorf("failed to parse STIG file: %w", err)
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
@@ -2,16 +2,17 @@ package processor
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
-	"gopkg.in/yaml.v3"
-
+	"github.com/stig-processor/pkg/document"
+	"github.com/stig-processor/pkg/filters"
 	"github.com/stig-processor/pkg/generator"
 	"github.com/stig-processor/pkg/parser"
+	"github.com/stig-processor/pkg/policyengine"
 	"github.com/stig-processor/pkg/types"
 )
 
@@ -86,6 +87,39 @@ func (sp *STIGProcessor) ProcessWithContext(ctx context.Context) (*types.Process
 		return nil, fmt.Errorf("input validation failed: %w", err)
 	}
 
+	if sp.options.StreamOutput {
+		result, err := sp.processStreamPipeline(ctx)
+		if err != nil {
+			return result, err
+		}
+		if err := sp.finalizeProcessing(result); err != nil {
+			return result, fmt.Errorf("finalization failed: %w", err)
+		}
+		return result, nil
+	}
+
+	if sp.options.Format == regoBundleFormat {
+		result, err := sp.processRegoBundle(ctx)
+		if err != nil {
+			return result, err
+		}
+		if err := sp.finalizeProcessing(result); err != nil {
+			return result, fmt.Errorf("finalization failed: %w", err)
+		}
+		return result, nil
+	}
+
+	if sp.options.Bundle == types.BundleFleetGitOps {
+		result, err := sp.processFleetGitOpsBundle(ctx)
+		if err != nil {
+			return result, err
+		}
+		if err := sp.finalizeProcessing(result); err != nil {
+			return result, fmt.Errorf("finalization failed: %w", err)
+		}
+		return result, nil
+	}
+
 	// Phase 2: Parse STIG file
 	stig, err := sp.parseSTIGFile(ctx)
 	if err != nil {
@@ -95,11 +129,81 @@ func (sp *STIGProcessor) ProcessWithContext(ctx context.Context) (*types.Process
 	// Phase 3: Filter rules if severity is specified
 	groups := sp.filterGroups(stig.Groups)
 
-	// Phase 4: Generate Fleet policies
+	// Phase 4: Evaluate decision policies, if configured
+	var policyErrors []types.ProcessingError
+	if sp.options.PoliciesDir != "" {
+		decisions, compileErrors, err := sp.evaluatePolicies(ctx, groups)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate decision policies: %w", err)
+		}
+		sp.generator.SetDecisions(decisions)
+		policyErrors = compileErrors
+	}
+
+	// Phase 4.5: Configure compliance filter/mapping, if configured
+	if err := sp.configureCompliance(); err != nil {
+		return nil, fmt.Errorf("failed to configure compliance filter: %w", err)
+	}
+
+	// Phase 5: Generate Fleet policies
 	result := sp.generatePolicies(ctx, groups)
+	result.Errors = append(result.Errors, policyErrors...)
+	result.Duration = time.Since(start)
+
+	// Phase 6: Post-process and finalize
+	if err := sp.finalizeProcessing(result); err != nil {
+		return result, fmt.Errorf("finalization failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// ProcessStreaming runs the same pipeline as ProcessWithContext, except
+// that policies are generated through
+// generator.BatchGenerateWithProgress, which emits a
+// types.ProcessingEvent on events after each group is classified (and,
+// if automatable, generated). events is
+// never closed by this method; the caller owns it. This backs the
+// JSON-RPC agent mode's per-rule progress notifications.
+func (sp *STIGProcessor) ProcessStreaming(ctx context.Context, events chan<- types.ProcessingEvent) (*types.ProcessingResult, error) {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, sp.options.Timeout)
+	defer cancel()
+
+	if err := sp.validateInputs(); err != nil {
+		return nil, fmt.Errorf("input validation failed: %w", err)
+	}
+
+	stig, err := sp.parseSTIGFile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse STIG file: %w", err)
+	}
+
+	groups := sp.filterGroups(stig.Groups)
+
+	if sp.options.PoliciesDir != "" {
+		decisions, _, err := sp.evaluatePolicies(ctx, groups)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate decision policies: %w", err)
+		}
+		sp.generator.SetDecisions(decisions)
+	}
+
+	if err := sp.configureCompliance(); err != nil {
+		return nil, fmt.Errorf("failed to configure compliance filter: %w", err)
+	}
+
+	progress := func(event types.ProcessingEvent) {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	}
+
+	result := sp.generator.BatchGenerateWithProgress(ctx, groups, progress)
 	result.Duration = time.Since(start)
 
-	// Phase 5: Post-process and finalize
 	if err := sp.finalizeProcessing(result); err != nil {
 		return result, fmt.Errorf("finalization failed: %w", err)
 	}
@@ -114,9 +218,42 @@ func (sp *STIGProcessor) validateInputs() error {
 		return fmt.Errorf("input file does not exist: %s", sp.options.InputFile)
 	}
 
-	// Validate output format
-	if sp.options.Format != "yaml" && sp.options.Format != "json" {
-		return fmt.Errorf("invalid output format: %s (must be 'yaml' or 'json')", sp.options.Format)
+	// Validate output format. rego-bundle isn't in formatRegistry: it
+	// doesn't render a single *types.FleetPolicy the way every registered
+	// Format's Marshal does, so it's checked separately.
+	if sp.options.Format != regoBundleFormat {
+		if _, ok := formatRegistry[sp.options.Format]; !ok {
+			return fmt.Errorf("invalid output format: %s (must be one of: %s, %s)", sp.options.Format, strings.Join(RegisteredFormats(), ", "), regoBundleFormat)
+		}
+	}
+
+	// Validate bundle layout if provided; empty keeps the flat OutputDir.
+	if sp.options.Bundle != "" && sp.options.Bundle != types.BundleFleetGitOps {
+		return fmt.Errorf("invalid bundle layout: %s (must be one of: %s)", sp.options.Bundle, types.BundleFleetGitOps)
+	}
+
+	// Validate input format if provided; empty defers to parser.DetectFormat.
+	if sp.options.InputFormat != "" {
+		switch sp.options.InputFormat {
+		case "xccdf", "scap", "cis-yaml", "stig-json":
+		default:
+			return fmt.Errorf("invalid input format: %s (must be one of: xccdf, scap, cis-yaml, stig-json)", sp.options.InputFormat)
+		}
+	}
+
+	// ParseSTIGFileStreaming only understands the pre-flattened STIG JSON
+	// shape - an XCCDF/SCAP/CIS-YAML source needs the whole document
+	// parsed with encoding/xml or yaml.v3 before any STIGGroup exists to
+	// stream, so it can't be decoded incrementally the way JSON's
+	// top-level "groups" array can.
+	if sp.options.StreamOutput {
+		format := sp.options.InputFormat
+		if format == "" {
+			format = parser.DetectFormat(sp.options.InputFile)
+		}
+		if format != "stig-json" {
+			return fmt.Errorf("StreamOutput only supports stig-json input, got %s", format)
+		}
 	}
 
 	// Validate severity filter if provided
@@ -140,10 +277,21 @@ func (sp *STIGProcessor) validateInputs() error {
 		}
 	}
 
+	// The decision policy engine evaluates every group up front (see
+	// evaluatePolicies), which is exactly the full-benchmark-in-memory
+	// step StreamOutput exists to avoid. Rather than silently generating
+	// policies without the configured decisions, refuse the combination.
+	if sp.options.StreamOutput && sp.options.PoliciesDir != "" {
+		return fmt.Errorf("StreamOutput and PoliciesDir cannot be used together")
+	}
+
 	return nil
 }
 
-// parseSTIGFile loads and parses the STIG JSON file
+// parseSTIGFile loads and parses sp.options.InputFile via
+// parser.STIGParser.LoadBenchmark, auto-detecting its format (XCCDF,
+// SCAP data stream, CIS YAML, or the pre-flattened STIG JSON) from its
+// extension unless sp.options.InputFormat overrides that.
 func (sp *STIGProcessor) parseSTIGFile(ctx context.Context) (*types.STIGBenchmark, error) {
 	// Check for context cancellation
 	select {
@@ -156,7 +304,7 @@ func (sp *STIGProcessor) parseSTIGFile(ctx context.Context) (*types.STIGBenchmar
 		fmt.Printf("Parsing STIG file: %s\n", sp.options.InputFile)
 	}
 
-	stig, err := sp.parser.ParseSTIGFile(sp.options.InputFile)
+	stig, err := sp.parser.LoadBenchmark(sp.options.InputFile, sp.options.InputFormat)
 	if err != nil {
 		return nil, err
 	}
@@ -197,7 +345,7 @@ func (sp *STIGProcessor) generatePolicies(ctx context.Context, groups []types.ST
 	}
 
 	// Use the generator's batch processing
-	result := sp.generator.BatchGenerate(groups)
+	result := sp.generator.BatchGenerate(ctx, groups)
 
 	// Check for context cancellation after processing
 	select {
@@ -214,6 +362,122 @@ func (sp *STIGProcessor) generatePolicies(ctx context.Context, groups []types.ST
 	return result
 }
 
+// evaluatePolicies builds a policyengine.Engine from the configured
+// PoliciesDir/CapabilitiesFile and evaluates it against groups. A policy
+// file that fails strict-mode compilation doesn't abort the run: it's
+// reported back as ProcessingErrors for the caller to merge into the
+// result alongside every other policy that did compile.
+func (sp *STIGProcessor) evaluatePolicies(ctx context.Context, groups []types.STIGGroup) ([]policyengine.RuleDecision, []types.ProcessingError, error) {
+	engine, compileErrors, err := policyengine.NewEngine(sp.options.PoliciesDir, sp.options.CapabilitiesFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if sp.options.Verbose && len(compileErrors) > 0 {
+		fmt.Printf("Policy engine: %d polic(ies) in %s failed to compile\n", len(compileErrors), sp.options.PoliciesDir)
+	}
+
+	decisions, err := engine.EvaluateRules(ctx, groups)
+	if err != nil {
+		return nil, compileErrors, err
+	}
+
+	return decisions, compileErrors, nil
+}
+
+// configureCompliance builds a pkg/filters.FilterSpec from
+// ProcessingOptions' Filter*/FilterConfigFile fields, loads a
+// ComplianceMappingFile, an OsqueryCapabilitiesFile, and an
+// EnforcementProfile/EnforcementProfileFile, installing whichever is
+// configured on sp.generator. A no-op (the generator keeps its embedded
+// default mapping, capabilities, and no extra filter or scoped
+// enforcement profile) when none of those options are set.
+func (sp *STIGProcessor) configureCompliance() error {
+	o := sp.options
+
+	if o.ComplianceMappingFile != "" {
+		mapping, err := filters.LoadMappingFile(o.ComplianceMappingFile)
+		if err != nil {
+			return err
+		}
+		sp.generator.SetComplianceMapping(mapping)
+	}
+
+	if o.OsqueryCapabilitiesFile != "" {
+		caps, err := generator.LoadOsqueryCapabilities(o.OsqueryCapabilitiesFile)
+		if err != nil {
+			return err
+		}
+		sp.generator.SetOsqueryCapabilities(caps)
+	}
+
+	if o.EnforcementProfile != "" || o.EnforcementProfileFile != "" {
+		profile, err := generator.ResolveEnforcementProfile(o.EnforcementProfile, o.EnforcementProfileFile)
+		if err != nil {
+			return err
+		}
+		sp.generator.SetEnforcementProfile(profile)
+	}
+
+	if o.FilterConfigFile != "" {
+		spec, err := filters.LoadFilterSpecFile(o.FilterConfigFile)
+		if err != nil {
+			return err
+		}
+		sp.generator.SetFilter(spec)
+		return nil
+	}
+
+	if o.CCIFilter != "" || o.NISTFamilyFilter != "" || o.CISBenchmarkFilter != "" || o.PlatformFilter != "" || o.GroupIDGlobFilter != "" {
+		spec := filters.ParseFilterSpec(o.Severity, o.CCIFilter, o.NISTFamilyFilter, o.CISBenchmarkFilter, o.PlatformFilter, o.GroupIDGlobFilter)
+		sp.generator.SetFilter(&spec)
+	}
+
+	return nil
+}
+
+// EvaluateRules parses the configured input file, applies the severity
+// filter, and evaluates the result against the configured decision
+// policies without generating any Fleet policies. It backs the "eval"
+// subcommand's dry-run inspection of what pkg/policyengine would decide.
+func (sp *STIGProcessor) EvaluateRules(ctx context.Context) ([]policyengine.RuleDecision, error) {
+	stig, err := sp.parseSTIGFile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse STIG file: %w", err)
+	}
+
+	groups := sp.filterGroups(stig.Groups)
+
+	decisions, _, err := sp.evaluatePolicies(ctx, groups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate decision policies: %w", err)
+	}
+
+	return decisions, nil
+}
+
+// WriteEvaluationReport attaches results - pass/fail outcomes observed
+// elsewhere, keyed by GroupID - to result.Policies and writes them as
+// results.json and an aggregate "stig-evaluation.sarif.json" in
+// outputDir. It backs the "report" subcommand, run after a normal
+// ProcessWithContext has already generated result.Policies.
+func (sp *STIGProcessor) WriteEvaluationReport(result *types.ProcessingResult, results []generator.RuleResult, outputDir string) error {
+	if err := sp.generator.WriteResultsSummary(result.Policies, results, outputDir); err != nil {
+		return err
+	}
+
+	data, err := generator.GenerateEvaluationSARIF(result.Policies, results, sp.options.Pretty)
+	if err != nil {
+		return fmt.Errorf("failed to generate evaluation SARIF: %w", err)
+	}
+
+	path := filepath.Join(outputDir, "stig-evaluation.sarif.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write evaluation SARIF %s: %w", path, err)
+	}
+	return nil
+}
+
 // finalizeProcessing performs final cleanup and validation
 func (sp *STIGProcessor) finalizeProcessing(result *types.ProcessingResult) error {
 	if sp.options.Verbose {
@@ -229,11 +493,17 @@ func (sp *STIGProcessor) finalizeProcessing(result *types.ProcessingResult) erro
 		}
 	}
 
-	// Validate that we generated some policies if we had automatable rules
-	if result.Automatable > 0 && len(result.Policies) == 0 {
+	// Validate that we generated some policies if we had automatable
+	// rules. Skipped in streaming mode and rego-bundle mode: both
+	// intentionally leave result.Policies empty, since neither collects
+	// *types.FleetPolicy values (streaming writes them as produced;
+	// rego-bundle renders RegoModules instead).
+	if !sp.options.StreamOutput && sp.options.Format != regoBundleFormat && result.Automatable > 0 && len(result.Policies) == 0 {
 		return fmt.Errorf("expected to generate policies but none were created")
 	}
 
+	sp.reportNarrativeCoverage(result)
+
 	// Check for critical errors that should fail the process
 	criticalErrors := sp.filterCriticalErrors(result.Errors)
 	if len(criticalErrors) > 0 {
@@ -243,6 +513,42 @@ func (sp *STIGProcessor) finalizeProcessing(result *types.ProcessingResult) erro
 	return nil
 }
 
+// reportNarrativeCoverage cross-references the Fleet policies just
+// generated against any pkg/document narrative Documents already present
+// in the output directory, printing which policies (and so which STIG
+// rules) still lack human-authored remediation narratives. It's a no-op
+// when not in verbose mode or when no narrative Documents are found.
+func (sp *STIGProcessor) reportNarrativeCoverage(result *types.ProcessingResult) {
+	if !sp.options.Verbose || sp.options.StreamOutput || sp.options.Format == regoBundleFormat {
+		return
+	}
+
+	narratives, err := document.ReadNarratives(sp.options.OutputDir)
+	if err != nil || len(narratives) == 0 {
+		return
+	}
+
+	enforced := make(map[string]bool)
+	for _, narrative := range narratives {
+		for _, policyName := range narrative.EnforcedBy {
+			enforced[policyName] = true
+		}
+	}
+
+	var undocumented []string
+	for _, policy := range result.Policies {
+		if !enforced[policy.Metadata.Name] {
+			undocumented = append(undocumented, policy.Metadata.Name)
+		}
+	}
+
+	fmt.Printf("\nNarrative coverage: %d/%d policies documented\n",
+		len(result.Policies)-len(undocumented), len(result.Policies))
+	for _, name := range undocumented {
+		fmt.Printf("  missing narrative: %s\n", name)
+	}
+}
+
 // filterCriticalErrors identifies errors that should cause the process to fail
 func (sp *STIGProcessor) filterCriticalErrors(errors []types.ProcessingError) []types.ProcessingError {
 	critical := make([]types.ProcessingError, 0)
@@ -260,7 +566,7 @@ func (sp *STIGProcessor) filterCriticalErrors(errors []types.ProcessingError) []
 
 // GetStatistics analyzes the STIG file and returns processing statistics
 func (sp *STIGProcessor) GetStatistics() (*types.ProcessingStatistics, error) {
-	stig, err := sp.parser.ParseSTIGFile(sp.options.InputFile)
+	stig, err := sp.parser.LoadBenchmark(sp.options.InputFile, sp.options.InputFormat)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse STIG file for statistics: %w", err)
 	}
@@ -299,74 +605,82 @@ func (sp *STIGProcessor) validatePolicyFiles(dir string) (*types.ValidationResul
 		}
 
 		filename := entry.Name()
-		if !strings.HasPrefix(filename, "stig-") {
-			continue // Not a STIG policy file
-		}
 
-		if strings.HasSuffix(filename, ".yaml") || strings.HasSuffix(filename, ".yml") {
-			if err := sp.validateYAMLFile(dir, filename); err != nil {
-				result.Valid = false
-				result.Errors = append(result.Errors, types.ValidationError{
-					FilePath: filename,
-					Message:  err.Error(),
-					Type:     types.ValidationErrorYAMLSyntax,
-				})
+		// MDMD documents (narratives, procedures, fleet-policy/stig-rule
+		// exports) aren't required to carry the "stig-" filename prefix
+		// generated YAML/JSON policy files use.
+		if strings.HasSuffix(filename, ".md") {
+			raw, err := os.ReadFile(fmt.Sprintf("%s/%s", dir, filename))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", filename, err)
 			}
-			result.Count++
-		} else if strings.HasSuffix(filename, ".json") {
-			if err := sp.validateJSONFile(dir, filename); err != nil {
+			doc, err := document.Parse(raw)
+			if err != nil {
+				continue // not an MDMD document, e.g. a plain README.md
+			}
+			if err := document.Validate(doc); err != nil {
 				result.Valid = false
 				result.Errors = append(result.Errors, types.ValidationError{
 					FilePath: filename,
 					Message:  err.Error(),
-					Type:     types.ValidationErrorJSONSyntax,
+					Type:     types.ValidationErrorDocumentSchema,
 				})
 			}
 			result.Count++
+			continue
 		}
-	}
 
-	return result, nil
-}
+		if !strings.HasPrefix(filename, "stig-") {
+			continue // Not a STIG policy file
+		}
 
-// validateYAMLFile validates a single YAML policy file
-func (sp *STIGProcessor) validateYAMLFile(dir, filename string) error {
-	filepath := fmt.Sprintf("%s/%s", dir, filename)
+		// WriteSummary's stig-summary.{json,yaml,sarif.json} is a
+		// types.STIGSummary, not a per-rule types.FleetPolicy - it
+		// doesn't belong to any registered Format and must not be run
+		// through the FleetPolicy validators below.
+		if strings.HasPrefix(filename, "stig-summary.") {
+			continue
+		}
 
-	data, err := os.ReadFile(filepath)
-	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
-	}
+		format, ok := formatForFile(filename)
+		if !ok {
+			continue // Not a format any registered Format claims
+		}
 
-	var policy types.FleetPolicy
-	if err := yaml.Unmarshal(data, &policy); err != nil {
-		return fmt.Errorf("invalid YAML syntax: %w", err)
+		data, err := os.ReadFile(fmt.Sprintf("%s/%s", dir, filename))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+
+		if err := validateAgainstFormat(format, data); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, types.ValidationError{
+				FilePath: filename,
+				Message:  err.Error(),
+				Type:     format.ErrorType,
+			})
+		}
+		result.Count++
 	}
 
-	// Basic Fleet policy validation
-	return sp.validateFleetPolicyStructure(&policy)
+	return result, nil
 }
 
-// validateJSONFile validates a single JSON policy file
-func (sp *STIGProcessor) validateJSONFile(dir, filename string) error {
-	filepath := fmt.Sprintf("%s/%s", dir, filename)
-
-	data, err := os.ReadFile(filepath)
+// validateAgainstFormat runs data through format's Unmarshal and Validate
+// in sequence, returning whichever step fails first.
+func validateAgainstFormat(format Format, data []byte) error {
+	v, err := format.Unmarshal(data)
 	if err != nil {
-		return fmt.Errorf("failed to read file: %w", err)
+		return err
 	}
-
-	var policy types.FleetPolicy
-	if err := json.Unmarshal(data, &policy); err != nil {
-		return fmt.Errorf("invalid JSON syntax: %w", err)
-	}
-
-	// Basic Fleet policy validation
-	return sp.validateFleetPolicyStructure(&policy)
+	return format.Validate(v)
 }
 
-// validateFleetPolicyStructure validates the structure of a Fleet policy
-func (sp *STIGProcessor) validateFleetPolicyStructure(policy *types.FleetPolicy) error {
+// validateFleetPolicyStructure validates the structure of a Fleet policy.
+// It's a free function (rather than an STIGProcessor method) so the
+// yaml/json entries in the format registry can use it as their
+// Validator without holding an STIGProcessor.
+func validateFleetPolicyStructure(policy *types.FleetPolicy) error {
 	if policy.APIVersion != types.FleetAPIVersion {
 		return fmt.Errorf("invalid apiVersion: expected %s, got %s", types.FleetAPIVersion, policy.APIVersion)
 	}
@@ -399,10 +713,19 @@ func (sp *STIGProcessor) ProcessingOptions() *types.ProcessingOptions {
 	return sp.options
 }
 
-// UpdateOptions updates the processing options
-func (sp *STIGProcessor) UpdateOptions(options *types.ProcessingOptions) {
-	if options != nil {
-		sp.options = options
-		sp.generator = generator.NewFleetPolicyGenerator(options)
+// UpdateOptions updates the processing options, refusing an output
+// format that isn't in the format registry.
+func (sp *STIGProcessor) UpdateOptions(options *types.ProcessingOptions) error {
+	if options == nil {
+		return nil
+	}
+	if options.Format != regoBundleFormat {
+		if _, ok := formatRegistry[options.Format]; !ok {
+			return fmt.Errorf("invalid output format: %s (must be one of: %s, %s)", options.Format, strings.Join(RegisteredFormats(), ", "), regoBundleFormat)
+		}
 	}
+
+	sp.options = options
+	sp.generator = generator.NewFleetPolicyGenerator(options)
+	return nil
 }
@@ -0,0 +1,58 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stig-processor/pkg/generator"
+	"github.com/stig-processor/pkg/types"
+)
+
+// regoBundleFormat is the ProcessingOptions.Format value that selects
+// processRegoBundle instead of the Fleet policy pipeline. It's deliberately
+// distinct from "rego" (see generator.FleetPolicyGenerator.WritePolicy),
+// which writes each rule's policy.Spec.Rego as its own .rego file
+// rather than a standalone OPA bundle.
+const regoBundleFormat = "rego-bundle"
+
+// processRegoBundle is the ProcessWithContext branch used when
+// options.Format is "rego-bundle": it parses and filters groups exactly
+// like the Fleet pipeline, but renders automatable groups as a
+// generator.RegoPolicyGenerator bundle (one .rego module per rule, plus
+// main.rego and a .manifest) instead of Fleet policy files.
+func (sp *STIGProcessor) processRegoBundle(ctx context.Context) (*types.ProcessingResult, error) {
+	start := time.Now()
+
+	stig, err := sp.parseSTIGFile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse STIG file: %w", err)
+	}
+	groups := sp.filterGroups(stig.Groups)
+
+	regGen := generator.NewRegoPolicyGenerator(sp.options)
+	result, modules := regGen.BatchGenerateBundle(groups)
+
+	select {
+	case <-ctx.Done():
+		result.Errors = append(result.Errors, types.ProcessingError{
+			Message:   "processing was cancelled",
+			Type:      types.ErrorTypeUnknown,
+			Timestamp: time.Now(),
+		})
+	default:
+	}
+
+	if !sp.options.DryRun {
+		if err := regGen.WriteBundle(modules, sp.options.OutputDir); err != nil {
+			result.Errors = append(result.Errors, types.ProcessingError{
+				Message:   fmt.Sprintf("failed to write rego bundle: %v", err),
+				Type:      types.ErrorTypeFileWriteFailed,
+				Timestamp: time.Now(),
+			})
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
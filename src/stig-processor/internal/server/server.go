@@ -0,0 +1,152 @@
+// Package server implements the "stig-processor server" subcommand: a
+// long-running control node that accepts agent connections and, for
+// each one, drives its Process/GetStatistics/ValidatePolicies/Cancel
+// JSON-RPC 2.0 methods, printing the per-rule progress notifications an
+// agent streams back and the final result it returns.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/stig-processor/pkg/rpc"
+	"github.com/stig-processor/pkg/types"
+)
+
+// Job describes the Process call to issue against each agent that
+// connects. It mirrors internal/agent.ProcessParams' wire shape; the two
+// aren't shared to keep internal/server decoupled from internal/agent.
+type Job struct {
+	InputFile        string `json:"inputFile"`
+	OutputDir        string `json:"outputDir"`
+	Format           string `json:"format"`
+	Severity         string `json:"severity"`
+	DryRun           bool   `json:"dryRun"`
+	Pretty           bool   `json:"pretty"`
+	PoliciesDir      string `json:"policiesDir,omitempty"`
+	CapabilitiesFile string `json:"capabilitiesFile,omitempty"`
+}
+
+// Options configures Run.
+type Options struct {
+	Addr string
+	Job  Job
+}
+
+// Run listens on opts.Addr. Each agent that connects is immediately
+// handed opts.Job via a "Process" call; its progress notifications are
+// printed as they arrive, followed by a summary of the final result.
+func Run(ctx context.Context, opts Options) error {
+	listener, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.Addr, err)
+	}
+	defer listener.Close()
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	fmt.Printf("stig-processor server listening on %s\n", opts.Addr)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+				return fmt.Errorf("accept failed: %w", err)
+			}
+		}
+
+		go handleAgent(ctx, conn, opts.Job)
+	}
+}
+
+// handleAgent drives one agent connection through its full call sequence:
+// GetStatistics against the job's input file (printed as a pre-flight
+// summary), Process itself, and finally ValidatePolicies against the
+// job's output directory to confirm what Process just wrote is valid. If
+// ctx is cancelled while Process is still in flight, it issues a Cancel
+// call for that job before returning.
+func handleAgent(ctx context.Context, conn net.Conn, job Job) {
+	defer conn.Close()
+
+	client := rpc.NewClient(rpc.NewConn(conn))
+	client.OnNotification = func(req *rpc.Request) {
+		if req.Method != "progress" {
+			return
+		}
+		var event types.ProcessingEvent
+		if err := json.Unmarshal(req.Params, &event); err != nil {
+			return
+		}
+		fmt.Printf("[%s] %s: %s (%s)\n", conn.RemoteAddr(), event.Type, event.Message, event.GroupID)
+	}
+
+	go client.Listen()
+
+	if resp, err := client.Call("GetStatistics", struct {
+		InputFile string `json:"inputFile"`
+	}{InputFile: job.InputFile}); err != nil {
+		fmt.Fprintf(os.Stderr, "agent %s: GetStatistics call failed: %v\n", conn.RemoteAddr(), err)
+	} else if resp.Error != nil {
+		fmt.Fprintf(os.Stderr, "agent %s: GetStatistics failed: %s\n", conn.RemoteAddr(), resp.Error.Message)
+	} else {
+		var stats types.ProcessingStatistics
+		if err := json.Unmarshal(resp.Result, &stats); err == nil {
+			fmt.Printf("agent %s: %d rules in %s (%s)\n", conn.RemoteAddr(), stats.TotalRules, stats.Title, stats.Version)
+		}
+	}
+
+	processDone := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			if resp, err := client.Call("Cancel", struct {
+				JobID string `json:"jobId"`
+			}{JobID: job.InputFile}); err == nil && resp.Error == nil {
+				fmt.Printf("agent %s: Cancel acknowledged for %s\n", conn.RemoteAddr(), job.InputFile)
+			}
+		case <-processDone:
+		}
+	}()
+
+	resp, err := client.Call("Process", job)
+	close(processDone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "agent %s: Process call failed: %v\n", conn.RemoteAddr(), err)
+		return
+	}
+	if resp.Error != nil {
+		fmt.Fprintf(os.Stderr, "agent %s: Process failed: %s\n", conn.RemoteAddr(), resp.Error.Message)
+		return
+	}
+
+	var result types.ProcessingResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		fmt.Fprintf(os.Stderr, "agent %s: failed to decode result: %v\n", conn.RemoteAddr(), err)
+		return
+	}
+
+	fmt.Printf("agent %s: %d policies generated, %d automatable, %d manual review\n",
+		conn.RemoteAddr(), len(result.Policies), result.Automatable, result.ManualReview)
+
+	if resp, err := client.Call("ValidatePolicies", struct {
+		OutputDir string `json:"outputDir"`
+	}{OutputDir: job.OutputDir}); err != nil {
+		fmt.Fprintf(os.Stderr, "agent %s: ValidatePolicies call failed: %v\n", conn.RemoteAddr(), err)
+	} else if resp.Error != nil {
+		fmt.Fprintf(os.Stderr, "agent %s: ValidatePolicies failed: %s\n", conn.RemoteAddr(), resp.Error.Message)
+	} else {
+		var validation types.ValidationResult
+		if err := json.Unmarshal(resp.Result, &validation); err == nil {
+			fmt.Printf("agent %s: validated %d policies, valid=%t\n", conn.RemoteAddr(), validation.Count, validation.Valid)
+		}
+	}
+}
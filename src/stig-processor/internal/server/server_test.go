@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stig-processor/pkg/rpc"
+	"github.com/stig-processor/pkg/types"
+)
+
+// TestHandleAgentCallsStatisticsProcessAndValidateInOrder drives
+// handleAgent over a net.Pipe against a hand-rolled agent-side
+// dispatcher, confirming it actually issues GetStatistics, Process, and
+// ValidatePolicies (not just Process) against one connected agent.
+func TestHandleAgentCallsStatisticsProcessAndValidateInOrder(t *testing.T) {
+	serverSide, agentSide := net.Pipe()
+	defer agentSide.Close()
+
+	var mu sync.Mutex
+	var calls []string
+
+	dispatcher := rpc.NewDispatcher()
+	dispatcher.Handle("GetStatistics", func(params json.RawMessage) (any, error) {
+		mu.Lock()
+		calls = append(calls, "GetStatistics")
+		mu.Unlock()
+		return types.ProcessingStatistics{TotalRules: 1, Title: "Test", Version: "1"}, nil
+	})
+	dispatcher.Handle("Process", func(params json.RawMessage) (any, error) {
+		mu.Lock()
+		calls = append(calls, "Process")
+		mu.Unlock()
+		return types.ProcessingResult{Total: 1, Automatable: 1}, nil
+	})
+	dispatcher.Handle("ValidatePolicies", func(params json.RawMessage) (any, error) {
+		mu.Lock()
+		calls = append(calls, "ValidatePolicies")
+		mu.Unlock()
+		return types.ValidationResult{Valid: true, Count: 1}, nil
+	})
+	dispatcher.Handle("Cancel", func(params json.RawMessage) (any, error) {
+		mu.Lock()
+		calls = append(calls, "Cancel")
+		mu.Unlock()
+		return map[string]bool{"cancelled": true}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serveDone := make(chan struct{})
+	go func() {
+		defer close(serveDone)
+		_ = dispatcher.Serve(ctx, rpc.NewConn(agentSide))
+	}()
+
+	handleDone := make(chan struct{})
+	go func() {
+		defer close(handleDone)
+		handleAgent(ctx, serverSide, Job{InputFile: "in.json", OutputDir: "out"})
+	}()
+
+	select {
+	case <-handleDone:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for handleAgent to finish")
+	}
+
+	mu.Lock()
+	got := append([]string(nil), calls...)
+	mu.Unlock()
+
+	want := []string{"GetStatistics", "Process", "ValidatePolicies"}
+	if len(got) != len(want) {
+		t.Fatalf("got calls %v, want %v", got, want)
+	}
+	for i, method := range want {
+		if got[i] != method {
+			t.Errorf("call %d: got %q, want %q", i, got[i], method)
+		}
+	}
+}
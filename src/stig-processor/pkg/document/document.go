@@ -0,0 +1,125 @@
+// Package document implements a convergent document model: STIG rules,
+// generated Fleet policies, and human-authored remediation narratives and
+// procedures can all be represented as a Document and stored on disk in
+// the same "MDMD" format (a markdown file with a YAML frontmatter block),
+// so a team can keep generated and hand-written compliance material in
+// one repo with first-class cross-references between them.
+//
+// pkg/generator and pkg/types' STIGGroup/FleetPolicy remain the structs
+// STIGProcessor's in-memory pipeline operates on; Document is the
+// on-disk convergence point that ReadPolicies/ReadNarratives/
+// ReadProcedures load from and Validate checks, not a replacement for
+// the generator's existing YAML/JSON output.
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kind discriminates what a Document represents.
+type Kind string
+
+const (
+	KindSTIGRule    Kind = "stig-rule"
+	KindFleetPolicy Kind = "fleet-policy"
+	KindNarrative   Kind = "narrative"
+	KindProcedure   Kind = "procedure"
+)
+
+// Document is one MDMD file: a YAML frontmatter block plus a markdown
+// body.
+type Document struct {
+	Kind   Kind              `yaml:"kind"`
+	Name   string            `yaml:"name"`
+	Title  string            `yaml:"title"`
+	Labels map[string]string `yaml:"labels,omitempty"`
+
+	// Satisfies names the stig-rule Documents a fleet-policy Document
+	// claims to enforce (e.g. ["V-253380"]).
+	Satisfies []string `yaml:"satisfies,omitempty"`
+	// EnforcedBy names the fleet-policy Documents a narrative or
+	// procedure Document claims back.
+	EnforcedBy []string `yaml:"enforcedBy,omitempty"`
+
+	// Body is the markdown content following the frontmatter block.
+	Body string `yaml:"-"`
+}
+
+const frontmatterDelim = "---"
+
+// Parse reads an MDMD document: a leading "---" line, a YAML frontmatter
+// block, a closing "---" line, then a markdown body.
+func Parse(data []byte) (*Document, error) {
+	text := string(data)
+
+	if !strings.HasPrefix(text, frontmatterDelim+"\n") {
+		return nil, fmt.Errorf("document must start with a %q frontmatter delimiter", frontmatterDelim)
+	}
+	rest := strings.TrimPrefix(text, frontmatterDelim+"\n")
+
+	end := strings.Index(rest, "\n"+frontmatterDelim)
+	if end < 0 {
+		return nil, fmt.Errorf("document is missing its closing %q frontmatter delimiter", frontmatterDelim)
+	}
+
+	var doc Document
+	if err := yaml.Unmarshal([]byte(rest[:end]), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+
+	body := rest[end+len("\n"+frontmatterDelim):]
+	doc.Body = strings.Trim(body, "\n")
+
+	if doc.Kind == "" {
+		return nil, fmt.Errorf("document is missing the required 'kind' field")
+	}
+
+	return &doc, nil
+}
+
+// Render serializes d back to MDMD form.
+func (d *Document) Render() ([]byte, error) {
+	frontmatter := *d
+	frontmatter.Body = ""
+
+	data, err := yaml.Marshal(&frontmatter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal frontmatter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(frontmatterDelim + "\n")
+	buf.Write(data)
+	buf.WriteString(frontmatterDelim + "\n\n")
+	buf.WriteString(d.Body)
+	buf.WriteString("\n")
+
+	return buf.Bytes(), nil
+}
+
+// Validate checks that d has a recognized Kind and the fields each Kind
+// requires to support cross-referencing.
+func Validate(doc *Document) error {
+	switch doc.Kind {
+	case KindSTIGRule, KindFleetPolicy, KindNarrative, KindProcedure:
+	default:
+		return fmt.Errorf("unknown document kind %q", doc.Kind)
+	}
+
+	if doc.Name == "" {
+		return fmt.Errorf("document is missing the required 'name' field")
+	}
+
+	if doc.Kind == KindFleetPolicy && len(doc.Satisfies) == 0 {
+		return fmt.Errorf("fleet-policy document %q must declare at least one 'satisfies' entry", doc.Name)
+	}
+	if (doc.Kind == KindNarrative || doc.Kind == KindProcedure) && len(doc.EnforcedBy) == 0 {
+		return fmt.Errorf("%s document %q must declare at least one 'enforcedBy' entry", doc.Kind, doc.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package document
+
+import "testing"
+
+func TestParseReadsFrontmatterAndBody(t *testing.T) {
+	data := []byte(`---
+kind: fleet-policy
+name: disable-telnet
+title: Disable Telnet
+satisfies:
+  - V-253380
+---
+
+# Disable Telnet
+
+Body text here.
+`)
+
+	doc, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if doc.Kind != KindFleetPolicy {
+		t.Errorf("got Kind %q, want %q", doc.Kind, KindFleetPolicy)
+	}
+	if doc.Name != "disable-telnet" {
+		t.Errorf("got Name %q, want disable-telnet", doc.Name)
+	}
+	if len(doc.Satisfies) != 1 || doc.Satisfies[0] != "V-253380" {
+		t.Errorf("got Satisfies %v, want [V-253380]", doc.Satisfies)
+	}
+	if doc.Body != "# Disable Telnet\n\nBody text here." {
+		t.Errorf("got Body %q", doc.Body)
+	}
+}
+
+func TestParseRejectsMissingDelimiters(t *testing.T) {
+	if _, err := Parse([]byte("no frontmatter here\n")); err == nil {
+		t.Error("expected Parse to reject a document with no opening delimiter")
+	}
+	if _, err := Parse([]byte("---\nkind: narrative\n")); err == nil {
+		t.Error("expected Parse to reject a document with no closing delimiter")
+	}
+}
+
+func TestParseRejectsMissingKind(t *testing.T) {
+	data := []byte("---\nname: x\n---\nbody\n")
+	if _, err := Parse(data); err == nil {
+		t.Error("expected Parse to reject a document with no 'kind' field")
+	}
+}
+
+func TestRenderThenParseRoundTrips(t *testing.T) {
+	doc := &Document{
+		Kind:      KindFleetPolicy,
+		Name:      "disable-telnet",
+		Title:     "Disable Telnet",
+		Satisfies: []string{"V-253380"},
+		Body:      "# Disable Telnet\n\nBody text here.",
+	}
+
+	rendered, err := doc.Render()
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	parsed, err := Parse(rendered)
+	if err != nil {
+		t.Fatalf("Parse(Render(doc)) failed: %v", err)
+	}
+	if parsed.Kind != doc.Kind || parsed.Name != doc.Name || parsed.Title != doc.Title {
+		t.Errorf("got %+v, want fields matching %+v", parsed, doc)
+	}
+	if parsed.Body != doc.Body {
+		t.Errorf("got Body %q, want %q", parsed.Body, doc.Body)
+	}
+}
+
+func TestValidateRequiresNameAndKnownKind(t *testing.T) {
+	if err := Validate(&Document{Kind: "bogus", Name: "x"}); err == nil {
+		t.Error("expected Validate to reject an unknown kind")
+	}
+	if err := Validate(&Document{Kind: KindNarrative}); err == nil {
+		t.Error("expected Validate to reject a missing name")
+	}
+}
+
+func TestValidateRequiresSatisfiesForFleetPolicy(t *testing.T) {
+	doc := &Document{Kind: KindFleetPolicy, Name: "x"}
+	if err := Validate(doc); err == nil {
+		t.Error("expected Validate to reject a fleet-policy document with no 'satisfies' entries")
+	}
+
+	doc.Satisfies = []string{"V-253380"}
+	if err := Validate(doc); err != nil {
+		t.Errorf("Validate failed on a valid fleet-policy document: %v", err)
+	}
+}
+
+func TestValidateRequiresEnforcedByForNarrativeAndProcedure(t *testing.T) {
+	for _, kind := range []Kind{KindNarrative, KindProcedure} {
+		doc := &Document{Kind: kind, Name: "x"}
+		if err := Validate(doc); err == nil {
+			t.Errorf("expected Validate to reject a %s document with no 'enforcedBy' entries", kind)
+		}
+
+		doc.EnforcedBy = []string{"disable-telnet"}
+		if err := Validate(doc); err != nil {
+			t.Errorf("Validate failed on a valid %s document: %v", kind, err)
+		}
+	}
+}
+
+func TestValidateAllowsSTIGRuleWithNoExtraFields(t *testing.T) {
+	if err := Validate(&Document{Kind: KindSTIGRule, Name: "V-253380"}); err != nil {
+		t.Errorf("Validate failed on a minimal stig-rule document: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package document
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReadDocuments loads every *.md file in dir as a Document, skipping
+// files that aren't MDMD (e.g. a README.md with no frontmatter) rather
+// than failing the whole read.
+func ReadDocuments(dir string) ([]*Document, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var docs []*Document
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		doc, err := Parse(data)
+		if err != nil {
+			continue
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// readKind loads every Document in dir whose Kind matches kind.
+func readKind(dir string, kind Kind) ([]*Document, error) {
+	docs, err := ReadDocuments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*Document
+	for _, doc := range docs {
+		if doc.Kind == kind {
+			matched = append(matched, doc)
+		}
+	}
+
+	return matched, nil
+}
+
+// ReadPolicies loads every fleet-policy Document in dir.
+func ReadPolicies(dir string) ([]*Document, error) {
+	return readKind(dir, KindFleetPolicy)
+}
+
+// ReadNarratives loads every narrative Document in dir.
+func ReadNarratives(dir string) ([]*Document, error) {
+	return readKind(dir, KindNarrative)
+}
+
+// ReadProcedures loads every procedure Document in dir.
+func ReadProcedures(dir string) ([]*Document, error) {
+	return readKind(dir, KindProcedure)
+}
+
+// CoverageReport summarizes which stig-rule Documents have a
+// fleet-policy Document satisfying them that is, in turn, backed by at
+// least one narrative or procedure Document.
+type CoverageReport struct {
+	TotalRules            int
+	DocumentedRules       int
+	UndocumentedRuleNames []string
+}
+
+// Coverage cross-references rules, policies, and narratives via their
+// Satisfies/EnforcedBy fields to build a CoverageReport.
+func Coverage(rules, policies, narratives []*Document) CoverageReport {
+	policiesForRule := make(map[string][]string) // rule name -> policy names
+	for _, policy := range policies {
+		for _, ruleName := range policy.Satisfies {
+			policiesForRule[ruleName] = append(policiesForRule[ruleName], policy.Name)
+		}
+	}
+
+	narratedPolicy := make(map[string]bool)
+	for _, narrative := range narratives {
+		for _, policyName := range narrative.EnforcedBy {
+			narratedPolicy[policyName] = true
+		}
+	}
+
+	report := CoverageReport{TotalRules: len(rules)}
+	for _, rule := range rules {
+		documented := false
+		for _, policyName := range policiesForRule[rule.Name] {
+			if narratedPolicy[policyName] {
+				documented = true
+				break
+			}
+		}
+		if documented {
+			report.DocumentedRules++
+		} else {
+			report.UndocumentedRuleNames = append(report.UndocumentedRuleNames, rule.Name)
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,95 @@
+package document
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeDoc(t *testing.T, dir, name string, doc *Document) {
+	t.Helper()
+	data, err := doc.Render()
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadDocumentsSkipsNonMDMDFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeDoc(t, dir, "policy.md", &Document{Kind: KindFleetPolicy, Name: "disable-telnet", Satisfies: []string{"V-1"}})
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Not a document\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored extension\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := ReadDocuments(dir)
+	if err != nil {
+		t.Fatalf("ReadDocuments failed: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("got %d docs, want 1 (README.md and notes.txt should be skipped)", len(docs))
+	}
+	if docs[0].Name != "disable-telnet" {
+		t.Errorf("got Name %q, want disable-telnet", docs[0].Name)
+	}
+}
+
+func TestReadPoliciesNarrativesProceduresFilterByKind(t *testing.T) {
+	dir := t.TempDir()
+	writeDoc(t, dir, "rule.md", &Document{Kind: KindSTIGRule, Name: "V-1"})
+	writeDoc(t, dir, "policy.md", &Document{Kind: KindFleetPolicy, Name: "p1", Satisfies: []string{"V-1"}})
+	writeDoc(t, dir, "narrative.md", &Document{Kind: KindNarrative, Name: "n1", EnforcedBy: []string{"p1"}})
+	writeDoc(t, dir, "procedure.md", &Document{Kind: KindProcedure, Name: "proc1", EnforcedBy: []string{"p1"}})
+
+	policies, err := ReadPolicies(dir)
+	if err != nil || len(policies) != 1 || policies[0].Name != "p1" {
+		t.Errorf("ReadPolicies = %+v, %v; want exactly [p1]", policies, err)
+	}
+
+	narratives, err := ReadNarratives(dir)
+	if err != nil || len(narratives) != 1 || narratives[0].Name != "n1" {
+		t.Errorf("ReadNarratives = %+v, %v; want exactly [n1]", narratives, err)
+	}
+
+	procedures, err := ReadProcedures(dir)
+	if err != nil || len(procedures) != 1 || procedures[0].Name != "proc1" {
+		t.Errorf("ReadProcedures = %+v, %v; want exactly [proc1]", procedures, err)
+	}
+}
+
+func TestCoverageRequiresBothPolicyAndNarrative(t *testing.T) {
+	rules := []*Document{
+		{Kind: KindSTIGRule, Name: "V-1"},
+		{Kind: KindSTIGRule, Name: "V-2"},
+		{Kind: KindSTIGRule, Name: "V-3"},
+	}
+	policies := []*Document{
+		{Kind: KindFleetPolicy, Name: "p1", Satisfies: []string{"V-1"}},
+		{Kind: KindFleetPolicy, Name: "p2", Satisfies: []string{"V-2"}},
+	}
+	narratives := []*Document{
+		{Kind: KindNarrative, Name: "n1", EnforcedBy: []string{"p1"}},
+	}
+
+	report := Coverage(rules, policies, narratives)
+	if report.TotalRules != 3 {
+		t.Errorf("got TotalRules %d, want 3", report.TotalRules)
+	}
+	if report.DocumentedRules != 1 {
+		t.Errorf("got DocumentedRules %d, want 1 (only V-1 has both a policy and a narrative)", report.DocumentedRules)
+	}
+	if len(report.UndocumentedRuleNames) != 2 {
+		t.Fatalf("got UndocumentedRuleNames %v, want 2 entries", report.UndocumentedRuleNames)
+	}
+	want := map[string]bool{"V-2": true, "V-3": true}
+	for _, name := range report.UndocumentedRuleNames {
+		if !want[name] {
+			t.Errorf("unexpected undocumented rule %q", name)
+		}
+	}
+}
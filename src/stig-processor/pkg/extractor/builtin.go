@@ -0,0 +1,185 @@
+package extractor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+func init() {
+	Register(serviceExtractor{})
+	Register(fileExtractor{})
+	Register(userGroupExtractor{})
+	Register(auditPolicyExtractor{})
+	Register(powerShellExtractor{})
+}
+
+var (
+	serviceStoppedPattern = regexp.MustCompile(`(?i)\b([A-Za-z0-9_\-]+)\s+service\b[^.]*\bmust\s+(?:be\s+)?(?:set\s+to\s+)?(disabled|stopped)\b`)
+	serviceRunningPattern = regexp.MustCompile(`(?i)\b([A-Za-z0-9_\-]+)\s+service\b[^.]*\bmust\s+(?:be\s+)?(?:set\s+to\s+)?(running|started)\b`)
+)
+
+// serviceExtractor recognizes "the <name> service must be
+// running/stopped/disabled" check content and renders it against
+// osquery's built-in services table.
+type serviceExtractor struct{}
+
+func (serviceExtractor) Name() string { return "service" }
+
+func (serviceExtractor) Extract(content string) (any, bool) {
+	if m := serviceStoppedPattern.FindStringSubmatch(content); m != nil {
+		return types.ServiceCheck{Name: m[1], State: strings.ToLower(m[2])}, true
+	}
+	if m := serviceRunningPattern.FindStringSubmatch(content); m != nil {
+		return types.ServiceCheck{Name: m[1], State: strings.ToLower(m[2])}, true
+	}
+	return nil, false
+}
+
+func (serviceExtractor) GenerateSQL(check any) string {
+	c := check.(types.ServiceCheck)
+	wantRunning := c.State == "running" || c.State == "started"
+	if wantRunning {
+		return fmt.Sprintf("SELECT * FROM services WHERE name = '%s' AND status = 'RUNNING';", c.Name)
+	}
+	return fmt.Sprintf("SELECT * FROM services WHERE name = '%s' AND status != 'RUNNING';", c.Name)
+}
+
+var (
+	fileMustExistPattern    = regexp.MustCompile(`(?i)\b(?:file|directory)\s+([\w./\\-]+)\s+must\s+exist\b`)
+	fileMustNotExistPattern = regexp.MustCompile(`(?i)\b(?:file|directory)\s+([\w./\\-]+)\s+must\s+not\s+exist\b`)
+)
+
+// fileExtractor recognizes "file/directory <path> must (not) exist"
+// check content and renders it against osquery's built-in file table.
+type fileExtractor struct{}
+
+func (fileExtractor) Name() string { return "file" }
+
+func (fileExtractor) Extract(content string) (any, bool) {
+	if m := fileMustNotExistPattern.FindStringSubmatch(content); m != nil {
+		return types.FileCheck{Path: m[1], Exists: false}, true
+	}
+	if m := fileMustExistPattern.FindStringSubmatch(content); m != nil {
+		return types.FileCheck{Path: m[1], Exists: true}, true
+	}
+	return nil, false
+}
+
+func (fileExtractor) GenerateSQL(check any) string {
+	c := check.(types.FileCheck)
+	if !c.Exists {
+		return fmt.Sprintf("SELECT 1 WHERE NOT EXISTS (SELECT 1 FROM file WHERE path = '%s');", c.Path)
+	}
+	return fmt.Sprintf("SELECT * FROM file WHERE path = '%s';", c.Path)
+}
+
+var userGroupMembersPattern = regexp.MustCompile(`(?i)\bmembers?\s+of\s+the\s+([A-Za-z0-9_\- ]+?)\s+group\s+must\s+(?:be\s+limited\s+to|only\s+be)\s+([A-Za-z0-9_\-,\s]+?)\s*\.?\s*$`)
+
+// userGroupExtractor recognizes "members of the <group> group must be
+// limited to <users>" check content and renders it against osquery's
+// built-in user_groups/users tables, flagging any member not in the
+// allow-list.
+type userGroupExtractor struct{}
+
+func (userGroupExtractor) Name() string { return "user" }
+
+func (userGroupExtractor) Extract(content string) (any, bool) {
+	m := userGroupMembersPattern.FindStringSubmatch(strings.TrimSpace(content))
+	if m == nil {
+		return nil, false
+	}
+	var allowed []string
+	for _, name := range strings.Split(m[2], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			allowed = append(allowed, name)
+		}
+	}
+	return types.UserGroupCheck{Group: strings.TrimSpace(m[1]), Allowed: allowed}, true
+}
+
+func (userGroupExtractor) GenerateSQL(check any) string {
+	c := check.(types.UserGroupCheck)
+	quoted := make([]string, len(c.Allowed))
+	for i, name := range c.Allowed {
+		quoted[i] = fmt.Sprintf("'%s'", name)
+	}
+	return fmt.Sprintf(
+		"SELECT u.username FROM users u JOIN user_groups ug ON u.uid = ug.uid JOIN groups g ON ug.gid = g.gid WHERE g.groupname = '%s' AND u.username NOT IN (%s);",
+		c.Group, strings.Join(quoted, ", "),
+	)
+}
+
+var auditPolicyPattern = regexp.MustCompile(`(?i)\b([A-Za-z ]+?)\s*/\s*([A-Za-z][A-Za-z ]+?)\s+audit\s+polic(?:y|ies)\s+must\s+be\s+set\s+to\s+success\s+and\s+failure\b`)
+
+// auditPolicyExtractor recognizes "<category>/<subcategory> audit
+// policy must be set to Success and Failure" check content and renders
+// it against the auditpol subcategory rows osquery's
+// windows_security_center-adjacent audit_policy_registry table exposes.
+type auditPolicyExtractor struct{}
+
+func (auditPolicyExtractor) Name() string { return "audit" }
+
+func (auditPolicyExtractor) Extract(content string) (any, bool) {
+	m := auditPolicyPattern.FindStringSubmatch(content)
+	if m == nil {
+		return nil, false
+	}
+	return types.AuditPolicyCheck{
+		Category:    strings.TrimSpace(m[1]),
+		Subcategory: strings.TrimSpace(m[2]),
+	}, true
+}
+
+func (auditPolicyExtractor) GenerateSQL(check any) string {
+	c := check.(types.AuditPolicyCheck)
+	return fmt.Sprintf(
+		"SELECT * FROM audit_policy_registry WHERE category = '%s' AND subcategory = '%s' AND failure_value = 1 AND success_value = 1;",
+		c.Category, c.Subcategory,
+	)
+}
+
+var (
+	powerShellEnabledPattern  = regexp.MustCompile(`(?i)\b(Turn\s+on\s+[A-Za-z ]+|Script\s+Block\s+Logging|Module\s+Logging|Transcription)\b[^.]*\bmust\s+be\s+enabled\b`)
+	powerShellDisabledPattern = regexp.MustCompile(`(?i)\b(Turn\s+on\s+[A-Za-z ]+|Script\s+Block\s+Logging|Module\s+Logging|Transcription)\b[^.]*\bmust\s+be\s+disabled\b`)
+)
+
+// powerShellExtractor recognizes PowerShell logging-setting check
+// content ("Turn on Script Block Logging must be enabled", and similar)
+// and renders it against the registry path Group Policy projects that
+// setting onto, the same way RegistryParser would if the check content
+// spelled out the key directly.
+type powerShellExtractor struct{}
+
+func (powerShellExtractor) Name() string { return "powershell" }
+
+func (powerShellExtractor) Extract(content string) (any, bool) {
+	if m := powerShellEnabledPattern.FindStringSubmatch(content); m != nil {
+		return types.PowerShellCheck{Setting: strings.TrimSpace(m[1]), ExpectedState: "enabled"}, true
+	}
+	if m := powerShellDisabledPattern.FindStringSubmatch(content); m != nil {
+		return types.PowerShellCheck{Setting: strings.TrimSpace(m[1]), ExpectedState: "disabled"}, true
+	}
+	return nil, false
+}
+
+func (powerShellExtractor) GenerateSQL(check any) string {
+	c := check.(types.PowerShellCheck)
+	want := 0
+	if c.ExpectedState == "enabled" {
+		want = 1
+	}
+	key := `ScriptBlockLogging\EnableScriptBlockLogging`
+	switch setting := strings.ToLower(c.Setting); {
+	case strings.Contains(setting, "module logging"):
+		key = `ModuleLogging\EnableModuleLogging`
+	case strings.Contains(setting, "transcription"):
+		key = `Transcription\EnableTranscripting`
+	}
+	return fmt.Sprintf(
+		"SELECT * FROM registry WHERE path = 'HKEY_LOCAL_MACHINE\\SOFTWARE\\Policies\\Microsoft\\Windows\\PowerShell\\%s' AND data = '%d';",
+		key, want,
+	)
+}
@@ -0,0 +1,130 @@
+package extractor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestServiceExtractorRecognizesRunningAndStopped(t *testing.T) {
+	e := serviceExtractor{}
+
+	check, ok := e.Extract("The Telnet service must be disabled.")
+	if !ok {
+		t.Fatal("expected Extract to recognize a disabled-service check")
+	}
+	sql := e.GenerateSQL(check)
+	if !strings.Contains(sql, "name = 'Telnet'") || !strings.Contains(sql, "!= 'RUNNING'") {
+		t.Errorf("got SQL %q, want a Telnet-not-running query", sql)
+	}
+
+	check, ok = e.Extract("The W3SVC service must be set to running.")
+	if !ok {
+		t.Fatal("expected Extract to recognize a running-service check")
+	}
+	sql = e.GenerateSQL(check)
+	if !strings.Contains(sql, "name = 'W3SVC'") || !strings.Contains(sql, "= 'RUNNING'") {
+		t.Errorf("got SQL %q, want a W3SVC-running query", sql)
+	}
+
+	if _, ok := e.Extract("Something unrelated."); ok {
+		t.Error("expected Extract to reject unrelated content")
+	}
+}
+
+func TestFileExtractorRecognizesExistsAndNotExists(t *testing.T) {
+	e := fileExtractor{}
+
+	check, ok := e.Extract(`The file malware.exe must not exist.`)
+	if !ok {
+		t.Fatal("expected Extract to recognize a must-not-exist check")
+	}
+	sql := e.GenerateSQL(check)
+	if !strings.Contains(sql, `malware.exe`) {
+		t.Errorf("got SQL %q, want the path included", sql)
+	}
+
+	if _, ok := e.Extract(`The directory required must exist.`); !ok {
+		t.Fatal("expected Extract to recognize a must-exist check")
+	}
+}
+
+func TestUserGroupExtractorParsesAllowList(t *testing.T) {
+	e := userGroupExtractor{}
+
+	check, ok := e.Extract("Members of the Administrators group must be limited to Admin1, Admin2.")
+	if !ok {
+		t.Fatal("expected Extract to recognize a group-membership check")
+	}
+	sql := e.GenerateSQL(check)
+	if !strings.Contains(sql, "g.groupname = 'Administrators'") {
+		t.Errorf("got SQL %q, want the group name included", sql)
+	}
+	if !strings.Contains(sql, "'Admin1'") || !strings.Contains(sql, "'Admin2'") {
+		t.Errorf("got SQL %q, want both allowed users included", sql)
+	}
+}
+
+func TestAuditPolicyExtractorParsesCategoryAndSubcategory(t *testing.T) {
+	e := auditPolicyExtractor{}
+
+	check, ok := e.Extract("The Logon/Logoff / Logon audit policy must be set to Success and Failure.")
+	if !ok {
+		t.Fatal("expected Extract to recognize an audit-policy check")
+	}
+	sql := e.GenerateSQL(check)
+	if !strings.Contains(sql, "category =") || !strings.Contains(sql, "subcategory =") {
+		t.Errorf("got SQL %q, want both category and subcategory filters", sql)
+	}
+}
+
+func TestPowerShellExtractorRecognizesEnabledAndDisabled(t *testing.T) {
+	e := powerShellExtractor{}
+
+	check, ok := e.Extract("PowerShell Script Block Logging must be enabled.")
+	if !ok {
+		t.Fatal("expected Extract to recognize an enabled PowerShell check")
+	}
+	sql := e.GenerateSQL(check)
+	if !strings.Contains(sql, "data = '1'") {
+		t.Errorf("got SQL %q, want data = '1' for an enabled check", sql)
+	}
+
+	check, ok = e.Extract("PowerShell Module Logging must be disabled.")
+	if !ok {
+		t.Fatal("expected Extract to recognize a disabled PowerShell check")
+	}
+	sql = e.GenerateSQL(check)
+	if !strings.Contains(sql, "data = '0'") {
+		t.Errorf("got SQL %q, want data = '0' for a disabled check", sql)
+	}
+}
+
+func TestExtractAllTriesEveryExtractorInOrder(t *testing.T) {
+	name, sql, found := ExtractAll("The Telnet service must be disabled.")
+	if !found {
+		t.Fatal("expected ExtractAll to find a matching extractor")
+	}
+	if name != "service" {
+		t.Errorf("got name %q, want service", name)
+	}
+	if sql == "" {
+		t.Error("expected ExtractAll to return non-empty SQL")
+	}
+
+	if _, _, found := ExtractAll("Completely unrecognizable check content."); found {
+		t.Error("expected ExtractAll to report not-found for unrecognized content")
+	}
+}
+
+func TestRegisteredListsBuiltinsInRegistrationOrder(t *testing.T) {
+	names := Registered()
+	want := []string{"service", "file", "user", "audit", "powershell"}
+	if len(names) != len(want) {
+		t.Fatalf("got %d registered extractors, want %d: %v", len(names), len(want), names)
+	}
+	for i, n := range want {
+		if names[i] != n {
+			t.Errorf("got Registered()[%d] = %q, want %q", i, names[i], n)
+		}
+	}
+}
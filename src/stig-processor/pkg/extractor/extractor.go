@@ -0,0 +1,62 @@
+// Package extractor recognizes STIG check styles RegistryParser and
+// GroupPolicyParser don't - service state, file existence, group
+// membership, audit policy, and PowerShell logging settings (no WMI
+// check style is recognized) - and renders each as osquery SQL, so
+// ClassifyGroup has another chance to mark a
+// rule automatable before falling back to pkg/generator's CEL backend
+// or giving up on it as manual review.
+package extractor
+
+// CheckExtractor recognizes one check style in a STIG rule's
+// RuleCheckContent and renders it as osquery SQL. Extract returns a
+// typed check value (ServiceCheck, FileCheck, ...); GenerateSQL only
+// ever receives a value its own Extract produced, so implementations are
+// free to type-assert without a second "is this mine" check.
+type CheckExtractor interface {
+	// Name identifies the extractor for ProcessingStatistics and
+	// Verbose logging - "service", "file", "user", "audit", or
+	// "powershell" for the built-ins.
+	Name() string
+	// Extract recognizes content and returns its parsed check, or
+	// ok=false if this extractor doesn't recognize content.
+	Extract(content string) (check any, ok bool)
+	// GenerateSQL renders check (always a value this extractor's
+	// Extract just returned) into osquery SQL.
+	GenerateSQL(check any) string
+}
+
+var registry []CheckExtractor
+
+// Register installs e, appending it to the list ExtractAll tries in
+// registration order. The built-in extractors register themselves from
+// this package's init() funcs; a caller wanting a custom check style
+// calls Register from its own init() func before NewSTIGProcessor runs.
+func Register(e CheckExtractor) {
+	registry = append(registry, e)
+}
+
+// Registered returns every registered extractor's Name(), in
+// registration order.
+func Registered() []string {
+	names := make([]string, 0, len(registry))
+	for _, e := range registry {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+// ExtractAll tries every registered extractor against content, in
+// registration order, and returns the first one that recognizes it -
+// name identifies which extractor matched (see ProcessingStatistics'
+// per-category counters), query is its rendered osquery SQL. found is
+// false if no registered extractor recognizes content.
+func ExtractAll(content string) (name string, query string, found bool) {
+	for _, e := range registry {
+		check, ok := e.Extract(content)
+		if !ok {
+			continue
+		}
+		return e.Name(), e.GenerateSQL(check), true
+	}
+	return "", "", false
+}
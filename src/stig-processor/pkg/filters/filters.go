@@ -0,0 +1,166 @@
+// Package filters narrows which STIGGroups a generator turns into
+// policies, beyond the plain severity check ProcessingOptions.Severity
+// already supports. A FilterSpec adds CCI, NIST 800-53 control family,
+// CIS benchmark, platform, and group ID glob dimensions, resolved
+// against a ComplianceMapping where the dimension isn't already present
+// on the group itself.
+package filters
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// FilterSpec narrows a batch run to groups matching every non-empty
+// dimension (an empty dimension is ignored, not treated as "match
+// nothing"). All string-slice dimensions are OR'd internally (any one
+// value matching is enough for that dimension) but AND'd against every
+// other non-empty dimension.
+type FilterSpec struct {
+	Severity      string   `yaml:"severity,omitempty"`
+	CCI           []string `yaml:"cci,omitempty"`
+	NISTFamilies  []string `yaml:"nist_families,omitempty"`
+	CISBenchmarks []string `yaml:"cis_benchmarks,omitempty"`
+	Platform      string   `yaml:"platform,omitempty"`
+	GroupIDGlobs  []string `yaml:"group_id_globs,omitempty"`
+}
+
+// ParseFilterSpec builds a FilterSpec from comma-separated CLI flag
+// values. Any argument may be empty, leaving that dimension unfiltered.
+func ParseFilterSpec(severity, cci, nistFamilies, cisBenchmarks, platform, groupIDGlobs string) FilterSpec {
+	return FilterSpec{
+		Severity:      severity,
+		CCI:           splitCommaList(cci),
+		NISTFamilies:  splitCommaList(nistFamilies),
+		CISBenchmarks: splitCommaList(cisBenchmarks),
+		Platform:      platform,
+		GroupIDGlobs:  splitCommaList(groupIDGlobs),
+	}
+}
+
+// LoadFilterSpecFile loads a FilterSpec from a YAML config file at path.
+func LoadFilterSpecFile(path string) (*FilterSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read filter spec file %s: %w", path, err)
+	}
+	var spec FilterSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse filter spec file %s: %w", path, err)
+	}
+	return &spec, nil
+}
+
+// Matches reports whether group passes every non-empty dimension of f.
+// platform is the value the caller has already resolved for group (see
+// FleetPolicyGenerator.resolvePlatform), since FilterSpec itself has no
+// way to derive it. mapping resolves group.RuleIdent (its CCI) to NIST
+// controls and CIS benchmarks for the NISTFamilies/CISBenchmarks
+// dimensions; a nil mapping resolves no controls, so NISTFamilies then
+// matches nothing and CISBenchmarks matches only group.ExternalIDs["cis"].
+func (f *FilterSpec) Matches(group *types.STIGGroup, platform string, mapping *ComplianceMapping) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.Severity != "" && !strings.EqualFold(group.RuleSeverity, f.Severity) {
+		return false
+	}
+
+	if len(f.CCI) > 0 && !containsFold(f.CCI, group.RuleIdent) {
+		return false
+	}
+
+	if len(f.NISTFamilies) > 0 {
+		families := ControlFamilies(mapping.ResolveNIST(group.RuleIdent))
+		if !anyContainsFold(f.NISTFamilies, families) {
+			return false
+		}
+	}
+
+	if len(f.CISBenchmarks) > 0 {
+		benchmarks := mapping.ResolveCIS(group.RuleIdent)
+		if cis := group.ExternalIDs["cis"]; cis != "" {
+			benchmarks = append(benchmarks, cis)
+		}
+		if !anyContainsFold(f.CISBenchmarks, benchmarks) {
+			return false
+		}
+	}
+
+	if f.Platform != "" && !platformMatches(f.Platform, platform) {
+		return false
+	}
+
+	if len(f.GroupIDGlobs) > 0 && !anyGlobMatches(f.GroupIDGlobs, group.GroupID) {
+		return false
+	}
+
+	return true
+}
+
+// platformMatches reports whether want is one of resolved's
+// comma-separated platform values (FleetPolicyGenerator joins multiple
+// platforms into a single string with strings.Join(platforms, ",")).
+func platformMatches(want, resolved string) bool {
+	for _, p := range strings.Split(resolved, ",") {
+		if strings.EqualFold(strings.TrimSpace(p), want) {
+			return true
+		}
+	}
+	return false
+}
+
+func anyGlobMatches(globs []string, groupID string) bool {
+	for _, glob := range globs {
+		if ok, err := filepath.Match(glob, groupID); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, want string) bool {
+	if want == "" {
+		return false
+	}
+	for _, v := range values {
+		if strings.EqualFold(v, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyContainsFold reports whether any element of want case-insensitively
+// equals any element of have.
+func anyContainsFold(want, have []string) bool {
+	for _, w := range want {
+		if containsFold(have, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCommaList splits a comma-separated string into trimmed,
+// non-empty entries. An empty or whitespace-only raw returns nil.
+func splitCommaList(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
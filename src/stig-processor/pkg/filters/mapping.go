@@ -0,0 +1,89 @@
+package filters
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+//go:embed mappings/compliance.json
+var embeddedMapping embed.FS
+
+// ComplianceMapping resolves a STIGGroup's CCI (types.STIGGroup.RuleIdent)
+// to the NIST 800-53 controls and CIS benchmark recommendations it
+// satisfies, so generated policies can be labeled and filtered by
+// framework in addition to their native STIG identity.
+type ComplianceMapping struct {
+	CCIToNIST map[string][]string `json:"cci_to_nist"`
+	CCIToCIS  map[string][]string `json:"cci_to_cis"`
+}
+
+// LoadDefaultMapping loads the small illustrative CCI-to-NIST/CIS table
+// embedded at mappings/compliance.json. It's a starting point, not an
+// authoritative cross-reference - operators with a fuller mapping should
+// load it with LoadMappingFile instead.
+func LoadDefaultMapping() (*ComplianceMapping, error) {
+	data, err := embeddedMapping.ReadFile("mappings/compliance.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded compliance mapping: %w", err)
+	}
+	return parseMapping(data)
+}
+
+// LoadMappingFile loads a ComplianceMapping from a JSON file at path,
+// replacing the embedded default entirely.
+func LoadMappingFile(path string) (*ComplianceMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compliance mapping file %s: %w", path, err)
+	}
+	return parseMapping(data)
+}
+
+func parseMapping(data []byte) (*ComplianceMapping, error) {
+	var mapping ComplianceMapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse compliance mapping: %w", err)
+	}
+	return &mapping, nil
+}
+
+// ResolveNIST returns the NIST 800-53 controls mapped to cci, or nil if
+// cci is empty or unmapped.
+func (m *ComplianceMapping) ResolveNIST(cci string) []string {
+	if m == nil || cci == "" {
+		return nil
+	}
+	return m.CCIToNIST[cci]
+}
+
+// ResolveCIS returns the CIS benchmark recommendations mapped to cci,
+// or nil if cci is empty or unmapped.
+func (m *ComplianceMapping) ResolveCIS(cci string) []string {
+	if m == nil || cci == "" {
+		return nil
+	}
+	return m.CCIToCIS[cci]
+}
+
+// ControlFamilies returns the deduplicated NIST control family prefixes
+// (the part of a control ID before its first "-", e.g. "AC-3" -> "AC")
+// present in controls, in first-seen order.
+func ControlFamilies(controls []string) []string {
+	seen := make(map[string]bool, len(controls))
+	var families []string
+	for _, control := range controls {
+		family := control
+		if i := strings.Index(control, "-"); i >= 0 {
+			family = control[:i]
+		}
+		if family == "" || seen[family] {
+			continue
+		}
+		seen[family] = true
+		families = append(families, family)
+	}
+	return families
+}
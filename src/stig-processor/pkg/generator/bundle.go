@@ -0,0 +1,178 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// BundleWriter lays policies out the way Fleet's GitOps workflow expects
+// a repo to be structured, in place of WritePolicy's flat
+// one-YAML-per-rule OutputDir: a teams/<team>.yml pointing at
+// policies/windows/*.yml, a top-level default.yml, an (empty, for now)
+// queries/ directory for shared query fragments, and a generated
+// README.md summarizing the team's coverage. It's stateless - every
+// method takes the policies/outputDir/team it needs as arguments - so a
+// single BundleWriter{} value is reused across runs.
+type BundleWriter struct{}
+
+// fleetGitOpsTeam is teams/<team>.yml's shape: the handful of top-level
+// keys Fleet's `fleetctl gitops` expects, with Policies referencing the
+// per-rule files WriteBundle writes under policies/windows/.
+type fleetGitOpsTeam struct {
+	Name     string                 `yaml:"name"`
+	Policies []fleetGitOpsPolicyRef `yaml:"policies"`
+}
+
+type fleetGitOpsPolicyRef struct {
+	Path string `yaml:"path"`
+}
+
+// fleetGitOpsDefault is the top-level default.yml's shape: an
+// org_settings stanza plus the list of team config files Fleet applies
+// alongside it.
+type fleetGitOpsDefault struct {
+	OrgSettings map[string]any `yaml:"org_settings"`
+	Teams       []string       `yaml:"teams"`
+}
+
+// WriteBundle writes policies into outputDir as a Fleet GitOps repo:
+//
+//	outputDir/
+//	  default.yml
+//	  teams/<team>.yml
+//	  teams/<team>.README.md
+//	  policies/windows/<rule>.yaml
+//	  queries/
+//	  bundle.hash
+//
+// team defaults to types.DefaultBundleTeam when empty. Every emitted
+// file's content is a deterministic function of policies and team -
+// policies is sorted by Metadata.Name first, same discipline
+// WriteSummary already applies to ProcessingResult.Policies - so re-running
+// WriteBundle over the same generated policies reproduces the same
+// bundle.hash byte for byte.
+func (w BundleWriter) WriteBundle(policies []types.FleetPolicy, outputDir, team string) error {
+	if team == "" {
+		team = types.DefaultBundleTeam
+	}
+
+	sorted := make([]types.FleetPolicy, len(policies))
+	copy(sorted, policies)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Metadata.Name < sorted[j].Metadata.Name
+	})
+
+	policiesDir := filepath.Join(outputDir, "policies", "windows")
+	teamsDir := filepath.Join(outputDir, "teams")
+	queriesDir := filepath.Join(outputDir, "queries")
+	for _, dir := range []string{policiesDir, teamsDir, queriesDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create bundle directory %s: %w", dir, err)
+		}
+	}
+
+	files := make(map[string][]byte)
+
+	teamRefs := make([]fleetGitOpsPolicyRef, 0, len(sorted))
+	for _, policy := range sorted {
+		data, err := yaml.Marshal(policy)
+		if err != nil {
+			return fmt.Errorf("failed to marshal policy %s: %w", policy.Metadata.Name, err)
+		}
+		filename := PolicyFilename(&policy, "yaml")
+		files[filepath.Join("policies", "windows", filename)] = data
+		teamRefs = append(teamRefs, fleetGitOpsPolicyRef{Path: "../policies/windows/" + filename})
+	}
+
+	teamDoc := fleetGitOpsTeam{Name: team, Policies: teamRefs}
+	teamData, err := yaml.Marshal(teamDoc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal team %s: %w", team, err)
+	}
+	files[filepath.Join("teams", team+".yml")] = teamData
+	files[filepath.Join("teams", team+".README.md")] = []byte(bundleReadme(team, sorted))
+
+	defaultDoc := fleetGitOpsDefault{
+		OrgSettings: map[string]any{"server_settings": map[string]any{}},
+		Teams:       []string{"teams/" + team + ".yml"},
+	}
+	defaultData, err := yaml.Marshal(defaultDoc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal default.yml: %w", err)
+	}
+	files["default.yml"] = defaultData
+
+	files["bundle.hash"] = []byte(bundleContentHash(files) + "\n")
+
+	for relPath, data := range files {
+		fullPath := filepath.Join(outputDir, relPath)
+		if err := os.WriteFile(fullPath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write bundle file %s: %w", fullPath, err)
+		}
+	}
+
+	return nil
+}
+
+// bundleContentHash hashes every file WriteBundle is about to write,
+// sorted by path so iteration order over the files map never affects the
+// result, giving a single hex digest that changes if and only if the
+// bundle's actual content would change.
+func bundleContentHash(files map[string][]byte) string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, path := range paths {
+		h.Write([]byte(path))
+		h.Write([]byte{0})
+		h.Write(files[path])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bundleReadme renders teams/<team>.README.md: a header, a policy count,
+// and a per-platform table of policies, in a stable (sorted) order so it
+// doesn't change between runs over the same generated policies.
+func bundleReadme(team string, policies []types.FleetPolicy) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s STIG policy coverage\n\n", team)
+	fmt.Fprintf(&b, "%d Fleet polic%s generated from this benchmark.\n\n", len(policies), pluralY(len(policies)))
+
+	counts := make(map[string]int)
+	for _, policy := range policies {
+		counts[strings.ToLower(policy.Spec.Platform)]++
+	}
+	platforms := make([]string, 0, len(counts))
+	for platform := range counts {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+
+	b.WriteString("| Platform | Policies |\n")
+	b.WriteString("|---|---|\n")
+	for _, platform := range platforms {
+		fmt.Fprintf(&b, "| %s | %d |\n", platform, counts[platform])
+	}
+
+	return b.String()
+}
+
+func pluralY(n int) string {
+	if n == 1 {
+		return "y"
+	}
+	return "ies"
+}
@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// OsqueryCapabilities lists the osquery tables --strict mode permits
+// generated SQL to reference, and each table's allowed columns - the
+// shape ProcessingOptions.OsqueryCapabilitiesFile loads via
+// LoadOsqueryCapabilities. It is unrelated to pkg/policyengine's
+// Capabilities, which gates decision-policy builtins rather than osquery
+// table/column names.
+type OsqueryCapabilities struct {
+	Tables map[string][]string `json:"tables"`
+}
+
+// defaultOsqueryCapabilities permits only what
+// parser.RegistryParser.GenerateOsquerySQL has ever emitted: the
+// registry table's path/data columns. A wider -osquery-capabilities file is
+// required before SQL referencing any other table (services, file,
+// wmi_*, ...) validates.
+func defaultOsqueryCapabilities() *OsqueryCapabilities {
+	return &OsqueryCapabilities{
+		Tables: map[string][]string{
+			"registry": {"path", "data"},
+		},
+	}
+}
+
+// LoadOsqueryCapabilities reads path as a JSON OsqueryCapabilities
+// document. An empty path returns defaultOsqueryCapabilities().
+func LoadOsqueryCapabilities(path string) (*OsqueryCapabilities, error) {
+	if path == "" {
+		return defaultOsqueryCapabilities(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read osquery capabilities file %s: %w", path, err)
+	}
+
+	var caps OsqueryCapabilities
+	if err := json.Unmarshal(data, &caps); err != nil {
+		return nil, fmt.Errorf("failed to parse osquery capabilities file %s: %w", path, err)
+	}
+	return &caps, nil
+}
+
+// allowsTable reports whether table matches one of caps.Tables' keys,
+// honoring a trailing "*" the way wmi_* is meant to (a shell-glob-style
+// prefix match, not a full regex).
+func (c *OsqueryCapabilities) allowsTable(table string) bool {
+	for pattern := range c.Tables {
+		if tablePatternMatches(pattern, table) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsColumn reports whether table.column is permitted by any matching
+// pattern in caps.Tables.
+func (c *OsqueryCapabilities) allowsColumn(table, column string) bool {
+	for pattern, columns := range c.Tables {
+		if !tablePatternMatches(pattern, table) {
+			continue
+		}
+		for _, col := range columns {
+			if col == column {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func tablePatternMatches(pattern, table string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(table, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == table
+}
+
+var (
+	sqlTablePattern         = regexp.MustCompile(`(?i)\bfrom\s+([a-z_][a-z0-9_]*)`)
+	sqlCastColumnPattern    = regexp.MustCompile(`(?i)\bcast\(\s*([a-z_][a-z0-9_]*)\s+as\b`)
+	sqlLengthColumnPattern  = regexp.MustCompile(`(?i)\blength\(\s*([a-z_][a-z0-9_]*)\s*\)`)
+	sqlCompareColumnPattern = regexp.MustCompile(`(?i)\b([a-z_][a-z0-9_]*)\s*(?:=|!=|>=|<=)`)
+	sqlIsNullColumnPattern  = regexp.MustCompile(`(?i)\b([a-z_][a-z0-9_]*)\s+is\s+(?:not\s+)?null\b`)
+)
+
+// checkOsqueryCapabilities reports every table and column query
+// references that caps doesn't allow, as human-readable issue strings -
+// empty means the query is entirely within caps.
+func checkOsqueryCapabilities(query string, caps *OsqueryCapabilities) []string {
+	var issues []string
+
+	tables := map[string]bool{}
+	for _, m := range sqlTablePattern.FindAllStringSubmatch(query, -1) {
+		table := strings.ToLower(m[1])
+		tables[table] = true
+		if !caps.allowsTable(table) {
+			issues = append(issues, fmt.Sprintf("references table %q, which is not in the capabilities file", table))
+		}
+	}
+
+	columns := map[string]bool{}
+	for _, pattern := range []*regexp.Regexp{sqlCastColumnPattern, sqlLengthColumnPattern, sqlCompareColumnPattern, sqlIsNullColumnPattern} {
+		for _, m := range pattern.FindAllStringSubmatch(query, -1) {
+			columns[strings.ToLower(m[1])] = true
+		}
+	}
+
+	for column := range columns {
+		allowed := false
+		for table := range tables {
+			if caps.allowsColumn(table, column) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			issues = append(issues, fmt.Sprintf("references column %q, which is not allowed for any referenced table", column))
+		}
+	}
+
+	return issues
+}
+
+var whereClausePattern = regexp.MustCompile(`(?is)\bwhere\b(.*?);?\s*$`)
+
+// checkOsqueryDeadCode flags the two dead-code shapes GenerateOsquerySQL
+// could emit if a registry check's value normalization regressed: a
+// WHERE clause with no predicates at all, and two top-level (paren-depth
+// 0) AND operands that are identical once whitespace is collapsed -
+// normally a sign the same registry value was rendered into the query
+// twice.
+func checkOsqueryDeadCode(query string) []string {
+	var issues []string
+
+	m := whereClausePattern.FindStringSubmatch(query)
+	if m == nil {
+		return issues
+	}
+	where := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(m[1]), ";"))
+	if where == "" {
+		return []string{"query has an empty predicate list (WHERE with no conditions)"}
+	}
+
+	seen := map[string]bool{}
+	for _, part := range splitTopLevelAnd(where) {
+		norm := strings.Join(strings.Fields(strings.ToLower(part)), " ")
+		if norm == "" {
+			continue
+		}
+		if seen[norm] {
+			issues = append(issues, fmt.Sprintf("duplicate AND condition: %s", strings.TrimSpace(part)))
+		}
+		seen[norm] = true
+	}
+	return issues
+}
+
+// splitTopLevelAnd splits s on " AND " (case-insensitive), ignoring any
+// " AND " nested inside parentheses - e.g. the AND joining two
+// conditions inside a single parenthesized registry check group is not a
+// top-level split point.
+func splitTopLevelAnd(s string) []string {
+	var parts []string
+	depth := 0
+	last := 0
+
+	lower := strings.ToLower(s)
+	i := 0
+	for i < len(s) {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 && strings.HasPrefix(lower[i:], " and ") {
+			parts = append(parts, s[last:i])
+			i += len(" and ")
+			last = i
+			continue
+		}
+		i++
+	}
+	parts = append(parts, s[last:])
+	return parts
+}
@@ -0,0 +1,481 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// celSchema documents every top-level field a synthesized CEL expression
+// may reference (host.services, host.users, ...) and the fields each
+// one's elements expose. CompileCEL rejects any host.<x> root or
+// bound-variable field access not listed here - whatever runs a "cel"
+// policy is responsible for projecting live osquery/Fleet host data into
+// this shape before evaluating the expression.
+var celSchema = map[string][]string{
+	"host.services": {"name", "status", "start_type"},
+	"host.users":    {"name", "groups", "enabled"},
+	"host.files":    {"path", "exists", "mode", "owner"},
+	"host.registry": {"hive", "path", "value_name", "value"},
+}
+
+// CELCheckGenerator synthesizes a CEL predicate for STIG rules that
+// RegistryParser.ParseRegistryCheck can't express as a registry
+// comparison - service, user/group, and file-existence checks described
+// in prose rather than a "HKEY_LOCAL_MACHINE\..." stanza. It's a
+// fallback ClassifyGroup reaches for once registry parsing has already
+// declined a group, not a replacement for it.
+//
+// Like RegoPolicyGenerator's ValidateRegoModule, CompileCEL below is a
+// hand-rolled stand-in for a real CEL compiler (this codebase vendors no
+// third-party Go modules at all - github.com/google/cel-go included - see
+// pkg/policyengine's own hand-rolled Rego-like evaluator for the same
+// tradeoff): it tokenizes expr and checks operand/operator alternation
+// and bracket nesting (celLex/checkTokenGrammar), then resolves
+// identifiers against celSchema, rejecting anything it can't bind. That
+// covers every expression SynthesizeExpression's templates can produce
+// plus any syntactically-malformed variant of them, but it is still not
+// a type-checker - it has no notion of CEL's value types, so it cannot
+// catch a schema-bound expression that's well-formed and fully resolved
+// but still ill-typed (e.g. comparing a string field against a list).
+type CELCheckGenerator struct {
+	parent *FleetPolicyGenerator
+}
+
+// NewCELCheckGenerator creates a CELCheckGenerator backed by parent's
+// registry-parser-adjacent helpers (buildResolutionText, resolvePlatform,
+// determineEnforcement, validatePolicy) so a CEL policy is built the
+// same way a registry-backed one is, minus the osquery/Rego rendering.
+func NewCELCheckGenerator(parent *FleetPolicyGenerator) *CELCheckGenerator {
+	return &CELCheckGenerator{parent: parent}
+}
+
+var (
+	serviceDisabledPattern = regexp.MustCompile(`(?i)\b([A-Za-z0-9_\-]+)\s+service\b[^.]*\b(disabled|stopped|not\s+running)\b`)
+	serviceRunningPattern  = regexp.MustCompile(`(?i)\b([A-Za-z0-9_\-]+)\s+service\b[^.]*\b(running|started|enabled)\b`)
+	userGroupPattern       = regexp.MustCompile(`(?i)\bmembers?\s+of\s+the\s+([A-Za-z0-9_\- ]+?)\s+group\b`)
+	fileExistsPattern      = regexp.MustCompile(`(?i)\bfile\s+([\w./\\-]+)\s+(?:exists|is present)\b`)
+)
+
+// SynthesizeExpression attempts to derive a CEL predicate from content
+// (group.RuleCheckContent), recognizing a handful of common STIG check
+// phrasings for service state, group membership, and file existence. ok
+// is false when none of those patterns match - the same "needs manual
+// review" signal ParseRegistryCheck gives.
+func SynthesizeExpression(content string) (expr string, ok bool) {
+	if m := serviceDisabledPattern.FindStringSubmatch(content); m != nil {
+		return fmt.Sprintf(`host.services.exists(s, s.name == %q && s.status in ["stopped", "disabled"])`, m[1]), true
+	}
+	if m := serviceRunningPattern.FindStringSubmatch(content); m != nil {
+		return fmt.Sprintf(`host.services.exists(s, s.name == %q && s.status == "running")`, m[1]), true
+	}
+	if m := userGroupPattern.FindStringSubmatch(content); m != nil {
+		return fmt.Sprintf(`!host.users.exists(u, u.enabled && u.groups.exists(g, g == %q))`, strings.TrimSpace(m[1])), true
+	}
+	if m := fileExistsPattern.FindStringSubmatch(content); m != nil {
+		return fmt.Sprintf(`host.files.exists(f, f.path == %q && f.exists)`, m[1]), true
+	}
+	return "", false
+}
+
+var (
+	hostRootRef  = regexp.MustCompile(`\bhost\.[a-zA-Z_]+\b`)
+	hostBoundVar = regexp.MustCompile(`(host\.[a-zA-Z_]+)\.exists\(\s*([a-zA-Z_]\w*)\s*,`)
+)
+
+// CompileCEL "compiles" expr against celSchema: it first tokenizes and
+// grammar-checks expr (see celLex/checkTokenGrammar below) so a syntax
+// error that two identifier-matching regexes would miss - an unterminated
+// string, a mismatched bracket, a stray operator, two operands with
+// nothing joining them - is rejected here rather than surfacing the first
+// time a real CEL evaluator runs the expression. It then resolves every
+// host.<x> root against celSchema, and every field access on a variable
+// bound by a ".exists(v, ...)" lambda against that root's documented
+// fields, returning an error naming the first unbound identifier it finds.
+func CompileCEL(expr string) error {
+	if strings.TrimSpace(expr) == "" {
+		return fmt.Errorf("expression is empty")
+	}
+
+	tokens, err := celLex(expr)
+	if err != nil {
+		return fmt.Errorf("syntax error: %w", err)
+	}
+	if err := checkTokenGrammar(tokens); err != nil {
+		return fmt.Errorf("syntax error: %w", err)
+	}
+
+	for _, root := range hostRootRef.FindAllString(expr, -1) {
+		if _, ok := celSchema[root]; !ok {
+			return fmt.Errorf("unbound identifier %q: not in the host.* schema", root)
+		}
+	}
+
+	for _, m := range hostBoundVar.FindAllStringSubmatch(expr, -1) {
+		root, varName := m[1], m[2]
+		fields := celSchema[root]
+
+		fieldRef := regexp.MustCompile(`\b` + regexp.QuoteMeta(varName) + `\.([a-zA-Z_]+)\b`)
+		for _, fm := range fieldRef.FindAllStringSubmatch(expr, -1) {
+			if !stringSliceContains(fields, fm[1]) {
+				return fmt.Errorf("unbound identifier %q: %s has no field %q", varName+"."+fm[1], root, fm[1])
+			}
+		}
+	}
+
+	return nil
+}
+
+// celTokenKind classifies a lexed token for checkTokenGrammar's
+// operand/operator alternation check. It is not a full CEL token set -
+// just the subset SynthesizeExpression's templates and celSchema-bound
+// expressions ever produce.
+type celTokenKind int
+
+const (
+	tokIdent celTokenKind = iota
+	tokString
+	tokNumber
+	tokOperator // ==, !=, &&, ||, in, <=, >=, <, >, .
+	tokNot      // ! (unary, valid wherever an operand is expected)
+	tokComma
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+)
+
+type celToken struct {
+	kind celTokenKind
+	text string
+}
+
+// celOperators lists every multi- and single-character CEL operator this
+// validator recognizes, longest first so celLex's greedy match doesn't
+// split "==" into two "=" tokens.
+var celOperators = []string{"==", "!=", "&&", "||", "<=", ">=", "<", ">"}
+
+// celLex tokenizes expr, returning an error for any character (or
+// unterminated string literal) that isn't part of a token this validator
+// understands - catching syntax errors a plain identifier-matching regex
+// would silently pass through.
+func celLex(expr string) ([]celToken, error) {
+	var tokens []celToken
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"':
+			j := i + 1
+			closed := false
+			for j < len(expr) {
+				if expr[j] == '\\' && j+1 < len(expr) {
+					j += 2
+					continue
+				}
+				if expr[j] == '"' {
+					closed = true
+					break
+				}
+				j++
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated string literal starting at offset %d", i)
+			}
+			tokens = append(tokens, celToken{tokString, expr[i : j+1]})
+			i = j + 1
+		case c == '(':
+			tokens = append(tokens, celToken{tokLParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, celToken{tokRParen, ")"})
+			i++
+		case c == '[':
+			tokens = append(tokens, celToken{tokLBracket, "["})
+			i++
+		case c == ']':
+			tokens = append(tokens, celToken{tokRBracket, "]"})
+			i++
+		case c == ',':
+			tokens = append(tokens, celToken{tokComma, ","})
+			i++
+		case c == '!':
+			if i+1 < len(expr) && expr[i+1] == '=' {
+				tokens = append(tokens, celToken{tokOperator, "!="})
+				i += 2
+			} else {
+				tokens = append(tokens, celToken{tokNot, "!"})
+				i++
+			}
+		case c == '.':
+			tokens = append(tokens, celToken{tokOperator, "."})
+			i++
+		case isCELIdentStart(c):
+			j := i + 1
+			for j < len(expr) && isCELIdentPart(expr[j]) {
+				j++
+			}
+			word := expr[i:j]
+			if word == "in" {
+				tokens = append(tokens, celToken{tokOperator, word})
+			} else {
+				tokens = append(tokens, celToken{tokIdent, word})
+			}
+			i = j
+		case c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(expr) && (expr[j] >= '0' && expr[j] <= '9' || expr[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, celToken{tokNumber, expr[i:j]})
+			i = j
+		default:
+			matched := false
+			for _, op := range celOperators {
+				if op == "<" || op == ">" {
+					continue // single-char forms handled after multi-char attempts below
+				}
+				if strings.HasPrefix(expr[i:], op) {
+					tokens = append(tokens, celToken{tokOperator, op})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if matched {
+				continue
+			}
+			if c == '<' || c == '>' {
+				tokens = append(tokens, celToken{tokOperator, string(c)})
+				i++
+				continue
+			}
+			return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+		}
+	}
+	return tokens, nil
+}
+
+func isCELIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isCELIdentPart(c byte) bool {
+	return isCELIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// checkTokenGrammar walks tokens enforcing that operands and operators
+// strictly alternate (catching e.g. two identifiers with no operator
+// between them, or an operator with nothing on one side) and that every
+// bracket/paren opens and closes in a properly nested order - the
+// structural checks a hand-rolled identifier scan can't express but a
+// real CEL parser would reject immediately.
+func checkTokenGrammar(tokens []celToken) error {
+	if len(tokens) == 0 {
+		return fmt.Errorf("expression has no tokens")
+	}
+
+	type frame byte
+	const (
+		frameParen frame = iota
+		frameBracket
+	)
+	var stack []frame
+
+	expectOperand := true
+	for idx, tok := range tokens {
+		switch tok.kind {
+		case tokIdent, tokString, tokNumber:
+			if !expectOperand {
+				return fmt.Errorf("unexpected %q at token %d: expected an operator", tok.text, idx)
+			}
+			expectOperand = false
+		case tokNot:
+			if !expectOperand {
+				return fmt.Errorf("unexpected %q at token %d: expected an operator", tok.text, idx)
+			}
+			// stays in "expect operand" state
+		case tokOperator:
+			if tok.text == "." {
+				// member access binds an operand to an operand (a.b), so it
+				// behaves like an operator that's immediately followed by
+				// another identifier rather than a general expression.
+				if expectOperand {
+					return fmt.Errorf("unexpected %q at token %d: expected an operand before \".\"", tok.text, idx)
+				}
+				expectOperand = true
+				continue
+			}
+			if expectOperand {
+				return fmt.Errorf("unexpected operator %q at token %d: expected an operand", tok.text, idx)
+			}
+			expectOperand = true
+		case tokComma:
+			if expectOperand {
+				return fmt.Errorf("unexpected \",\" at token %d: expected an operand", idx)
+			}
+			expectOperand = true
+		case tokLParen:
+			// "(" opens either a grouped sub-expression (expected where an
+			// operand would go) or a function/macro call's argument list
+			// immediately after an identifier (e.g. "exists("), which is
+			// valid even though an operand isn't expected there.
+			precededByCallable := idx > 0 && tokens[idx-1].kind == tokIdent
+			if !expectOperand && !precededByCallable {
+				return fmt.Errorf("unexpected \"(\" at token %d: expected an operator", idx)
+			}
+			stack = append(stack, frameParen)
+			expectOperand = true
+		case tokRParen:
+			if expectOperand && !(idx > 0 && tokens[idx-1].kind == tokLParen) {
+				return fmt.Errorf("unexpected \")\" at token %d: expected an operand", idx)
+			}
+			if len(stack) == 0 || stack[len(stack)-1] != frameParen {
+				return fmt.Errorf("unmatched \")\" at token %d", idx)
+			}
+			stack = stack[:len(stack)-1]
+			expectOperand = false
+		case tokLBracket:
+			if !expectOperand {
+				return fmt.Errorf("unexpected \"[\" at token %d: expected an operator", idx)
+			}
+			stack = append(stack, frameBracket)
+		case tokRBracket:
+			if expectOperand && len(tokens) > 0 && idx > 0 && tokens[idx-1].kind != tokLBracket {
+				return fmt.Errorf("unexpected \"]\" at token %d: expected an operand", idx)
+			}
+			if len(stack) == 0 || stack[len(stack)-1] != frameBracket {
+				return fmt.Errorf("unmatched \"]\" at token %d", idx)
+			}
+			stack = stack[:len(stack)-1]
+			expectOperand = false
+		}
+	}
+
+	if expectOperand {
+		return fmt.Errorf("expression ends with a dangling operator")
+	}
+	if len(stack) != 0 {
+		return fmt.Errorf("expression has %d unclosed bracket(s)/paren(s)", len(stack))
+	}
+
+	return nil
+}
+
+func stringSliceContains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateCheck synthesizes and compiles a CEL expression for group,
+// returning a FleetPolicy with Spec.Language "cel" and Spec.Expression
+// set, in place of Spec.Query/Spec.Rego. ok is false (not an error) when
+// SynthesizeExpression recognizes no pattern in group.RuleCheckContent -
+// ClassifyGroup treats that the same as ParseRegistryCheck declining a
+// group. A recognized pattern that fails to compile is an error, since
+// it means celSchema and the synthesizer have drifted apart.
+func (c *CELCheckGenerator) GenerateCheck(group *types.STIGGroup) (policy *types.FleetPolicy, ok bool, err error) {
+	if group == nil {
+		return nil, false, fmt.Errorf("group cannot be nil")
+	}
+
+	expr, matched := SynthesizeExpression(group.RuleCheckContent)
+	if !matched {
+		return nil, false, nil
+	}
+
+	if err := CompileCEL(expr); err != nil {
+		return nil, true, fmt.Errorf("failed to compile synthesized cel expression for %s: %w", group.GroupID, err)
+	}
+
+	g := c.parent
+	policyName := g.sanitizePolicyName(fmt.Sprintf("stig-%s-%s-cel", group.GroupID, group.RuleVersion))
+
+	labels := map[string]string{
+		"stig.group_id":     group.GroupID,
+		"stig.rule_version": group.RuleVersion,
+		"stig.severity":     strings.ToLower(group.RuleSeverity),
+		"stig.rule_id":      group.RuleID,
+		"compliance.type":   "stig",
+		"compliance.source": "disa",
+	}
+
+	annotations := map[string]string{
+		"stig.rule_weight":    group.RuleWeight,
+		"stig.rule_ident":     group.RuleIdent,
+		"stig.check_system":   group.RuleCheckSystem,
+		"stig.fix_id":         group.RuleFixID,
+		"generated.timestamp": time.Now().UTC().Format(time.RFC3339),
+		"generated.tool":      "stig-processor",
+	}
+	for k, v := range g.complianceAnnotations(group) {
+		annotations[k] = v
+	}
+
+	policy = &types.FleetPolicy{
+		APIVersion: types.FleetAPIVersion,
+		Kind:       types.FleetKindPolicy,
+		Metadata: types.PolicyMeta{
+			Name:        policyName,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: types.PolicySpec{
+			Name:        fmt.Sprintf("STIG %s: %s", group.GroupID, group.RuleTitle),
+			Language:    "cel",
+			Expression:  expr,
+			Description: c.buildDescription(group, expr),
+			Resolution:  g.buildResolutionText(group),
+			Platform:    g.resolvePlatform(group),
+			Critical:    strings.EqualFold(group.RuleSeverity, string(types.SeverityHigh)),
+			Enforcement: g.determineEnforcement(group),
+		},
+	}
+
+	if err := g.validatePolicy(policy); err != nil {
+		return nil, true, fmt.Errorf("generated cel policy failed validation: %w", err)
+	}
+
+	return policy, true, nil
+}
+
+// buildDescription renders group+expr the same way
+// FleetPolicyGenerator.buildPolicyDescription renders a registry-backed
+// policy's description, substituting the synthesized CEL expression for
+// the registry check details section that doesn't apply here.
+func (c *CELCheckGenerator) buildDescription(group *types.STIGGroup, expr string) string {
+	g := c.parent
+	var desc strings.Builder
+
+	desc.WriteString(fmt.Sprintf("STIG Rule %s (Severity: %s)\n\n", group.GroupID, group.RuleSeverity))
+
+	if group.RuleVulnDiscussion != "" {
+		desc.WriteString("Vulnerability Discussion:\n")
+		desc.WriteString(g.formatTextBlock(group.RuleVulnDiscussion))
+		desc.WriteString("\n\n")
+	}
+
+	desc.WriteString("Check Content:\n")
+	desc.WriteString(g.formatTextBlock(group.RuleCheckContent))
+	desc.WriteString("\n\n")
+
+	desc.WriteString("CEL Expression:\n")
+	desc.WriteString(expr)
+	desc.WriteString("\n")
+
+	if group.RuleIdent != "" {
+		desc.WriteString(fmt.Sprintf("\nCCI: %s\n", group.RuleIdent))
+	}
+
+	return desc.String()
+}
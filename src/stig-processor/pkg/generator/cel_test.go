@@ -0,0 +1,62 @@
+package generator
+
+import "testing"
+
+func TestCompileCELAcceptsSynthesizedExpressions(t *testing.T) {
+	exprs := []string{
+		`host.services.exists(s, s.name == "Telnet" && s.status in ["stopped", "disabled"])`,
+		`host.services.exists(s, s.name == "Telnet" && s.status == "running")`,
+		`!host.users.exists(u, u.enabled && u.groups.exists(g, g == "Guests"))`,
+		`host.files.exists(f, f.path == "C:\\foo" && f.exists)`,
+	}
+	for _, expr := range exprs {
+		if err := CompileCEL(expr); err != nil {
+			t.Errorf("CompileCEL(%q) = %v, want nil", expr, err)
+		}
+	}
+}
+
+func TestCompileCELRejectsSyntaxErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		expr string
+	}{
+		{"missing comma in lambda params", `host.services.exists(s s.name == "x")`},
+		{"single equals is not CEL equality", `host.services.exists(s, s.name = "x")`},
+		{"unterminated string literal", `host.services.exists(s, s.name == "x)`},
+		{"dangling trailing operator", `host.services.exists(s, s.name == "x") &&`},
+		{"unbalanced parentheses", `host.services.exists(s, (s.name == "x")`},
+		{"two operands with no operator between them", `host.services.exists(s, s.name == "x" "y")`},
+		{"unmatched closing bracket", `host.services.exists(s, s.status in ["x"]])`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := CompileCEL(tc.expr); err == nil {
+				t.Errorf("CompileCEL(%q) = nil, want a syntax error", tc.expr)
+			}
+		})
+	}
+}
+
+func TestCompileCELRejectsUnboundSchemaReferences(t *testing.T) {
+	cases := []struct {
+		name string
+		expr string
+	}{
+		{"unknown host root", `host.processes.exists(p, p.name == "x")`},
+		{"unknown field on bound variable", `host.services.exists(s, s.pid == 1)`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := CompileCEL(tc.expr); err == nil {
+				t.Errorf("CompileCEL(%q) = nil, want an unbound identifier error", tc.expr)
+			}
+		})
+	}
+}
+
+func TestCompileCELRejectsEmptyExpression(t *testing.T) {
+	if err := CompileCEL("   "); err == nil {
+		t.Error("CompileCEL(\"   \") = nil, want an error")
+	}
+}
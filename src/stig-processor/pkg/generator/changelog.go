@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// ChangelogDiff is the result of comparing two versions of the same STIG
+// benchmark's rules by GroupID: which groups only the newer benchmark
+// has, which only the older one has, and which exist in both but changed
+// severity. Unlike internal/processor.ProcessDiff's manifest-based diff
+// (this tool's own prior run vs. its current one), ChangelogDiff compares
+// two raw STIG sources directly, so it works even on a first-ever run.
+type ChangelogDiff struct {
+	Added           []types.STIGGroup
+	Removed         []types.STIGGroup
+	SeverityChanged []SeverityChange
+}
+
+// SeverityChange is one GroupID whose RuleSeverity differs between the
+// old and new benchmark.
+type SeverityChange struct {
+	GroupID     string
+	Title       string
+	OldSeverity string
+	NewSeverity string
+}
+
+// DiffBenchmarks compares old against current by GroupID, reporting
+// groups added, removed, or changed in severity. Groups present in both
+// with an identical RuleSeverity aren't reported - a title/check-content
+// wording change alone isn't a "change" this diff tracks.
+func DiffBenchmarks(old, current *types.STIGBenchmark) ChangelogDiff {
+	oldByID := make(map[string]types.STIGGroup, len(old.Groups))
+	for _, g := range old.Groups {
+		oldByID[g.GroupID] = g
+	}
+	currentByID := make(map[string]types.STIGGroup, len(current.Groups))
+	for _, g := range current.Groups {
+		currentByID[g.GroupID] = g
+	}
+
+	var diff ChangelogDiff
+	for id, group := range currentByID {
+		oldGroup, existed := oldByID[id]
+		if !existed {
+			diff.Added = append(diff.Added, group)
+			continue
+		}
+		if oldGroup.RuleSeverity != group.RuleSeverity {
+			diff.SeverityChanged = append(diff.SeverityChanged, SeverityChange{
+				GroupID:     id,
+				Title:       group.Title,
+				OldSeverity: oldGroup.RuleSeverity,
+				NewSeverity: group.RuleSeverity,
+			})
+		}
+	}
+	for id, group := range oldByID {
+		if _, stillPresent := currentByID[id]; !stillPresent {
+			diff.Removed = append(diff.Removed, group)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].GroupID < diff.Added[j].GroupID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].GroupID < diff.Removed[j].GroupID })
+	sort.Slice(diff.SeverityChanged, func(i, j int) bool {
+		return diff.SeverityChanged[i].GroupID < diff.SeverityChanged[j].GroupID
+	})
+
+	return diff
+}
+
+// WriteChangelog renders diff as CHANGELOG.md under outputDir, sectioned
+// into added/removed/severity-changed rules. old and current name the two
+// benchmark versions being compared (typically their Version field) for
+// the changelog's header.
+func WriteChangelog(diff ChangelogDiff, old, current, outputDir string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Changelog: %s -> %s\n\n", old, current)
+
+	writeGroupSection(&b, "Added", diff.Added)
+	writeGroupSection(&b, "Removed", diff.Removed)
+
+	fmt.Fprintf(&b, "## Severity changed (%d)\n\n", len(diff.SeverityChanged))
+	if len(diff.SeverityChanged) == 0 {
+		b.WriteString("None.\n\n")
+	} else {
+		b.WriteString("| GroupID | Title | Old severity | New severity |\n")
+		b.WriteString("|---|---|---|---|\n")
+		for _, c := range diff.SeverityChanged {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", c.GroupID, c.Title, c.OldSeverity, c.NewSeverity)
+		}
+		b.WriteString("\n")
+	}
+
+	path := filepath.Join(outputDir, "CHANGELOG.md")
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write changelog %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeGroupSection renders one "## <title> (n)" section listing groups
+// by GroupID and Title, or "None." when there are none.
+func writeGroupSection(b *strings.Builder, title string, groups []types.STIGGroup) {
+	fmt.Fprintf(b, "## %s (%d)\n\n", title, len(groups))
+	if len(groups) == 0 {
+		b.WriteString("None.\n\n")
+		return
+	}
+	for _, g := range groups {
+		fmt.Fprintf(b, "- **%s**: %s (%s)\n", g.GroupID, g.Title, g.RuleSeverity)
+	}
+	b.WriteString("\n")
+}
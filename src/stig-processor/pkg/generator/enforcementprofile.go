@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// builtinEnforcementProfiles are the named per-severity
+// PolicySpec.ScopedEnforcementActions mappings -enforcement-profile
+// selects by name. Unlike ProcessingOptions.EnforcementActions (a single
+// action broadcast to every enforcement point - see determineEnforcement),
+// a scoped profile can deny at one point while only warning at another
+// for the same rule.
+var builtinEnforcementProfiles = map[string]map[string][]types.EnforcementAction{
+	// "default" treats a STIG severity the way this repo already treats
+	// CAT level elsewhere (see types.STIGGroup.RuleSeverity): CAT
+	// I/high blocks at the webhook enforcement point but only warns in
+	// audit reporting, CAT II/medium warns, and CAT III/low merely
+	// notifies.
+	"default": {
+		string(types.SeverityHigh): {
+			{Action: types.EnforcementDeny, Scope: types.EnforcementPointWebhook},
+			{Action: types.EnforcementWarn, Scope: types.EnforcementPointAudit},
+		},
+		string(types.SeverityMedium): {
+			{Action: types.EnforcementWarn, Scope: types.EnforcementPointAudit},
+		},
+		string(types.SeverityLow): {
+			{Action: types.EnforcementNotify, Scope: types.EnforcementPointAudit},
+		},
+	},
+	// "strict" additionally denies at runtime for medium severity and
+	// denies outright (rather than just warning) high severity at audit,
+	// for operators enforcing ahead of what "default" is comfortable
+	// broadcasting everywhere.
+	"strict": {
+		string(types.SeverityHigh): {
+			{Action: types.EnforcementDeny, Scope: types.EnforcementPointWebhook},
+			{Action: types.EnforcementDeny, Scope: types.EnforcementPointAudit},
+			{Action: types.EnforcementDeny, Scope: types.EnforcementPointRuntime},
+		},
+		string(types.SeverityMedium): {
+			{Action: types.EnforcementWarn, Scope: types.EnforcementPointAudit},
+			{Action: types.EnforcementDeny, Scope: types.EnforcementPointRuntime},
+		},
+		string(types.SeverityLow): {
+			{Action: types.EnforcementNotify, Scope: types.EnforcementPointAudit},
+		},
+	},
+}
+
+// ResolveEnforcementProfile builds the per-severity EnforcementAction
+// mapping -enforcement-profile/-enforcement-profile-file select: profile
+// names a builtinEnforcementProfiles entry (empty is valid and yields a
+// nil mapping, same as neither flag being set), and overridesFile, if
+// set, loads a JSON file of the same shape
+// (`{"high": [{"action": "deny", "scope": "webhook"}, ...], ...}`) whose
+// entries replace the named profile's entry for that severity outright
+// rather than merging point-by-point. overridesFile alone, with no
+// profile name, is valid too.
+func ResolveEnforcementProfile(profile, overridesFile string) (map[string][]types.EnforcementAction, error) {
+	var mapping map[string][]types.EnforcementAction
+
+	if profile != "" {
+		builtin, ok := builtinEnforcementProfiles[profile]
+		if !ok {
+			return nil, fmt.Errorf("unknown enforcement profile %q", profile)
+		}
+		mapping = make(map[string][]types.EnforcementAction, len(builtin))
+		for severity, actions := range builtin {
+			mapping[severity] = actions
+		}
+	}
+
+	if overridesFile == "" {
+		return mapping, nil
+	}
+
+	data, err := os.ReadFile(overridesFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read enforcement profile overrides file %s: %w", overridesFile, err)
+	}
+	var overrides map[string][]types.EnforcementAction
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse enforcement profile overrides file %s: %w", overridesFile, err)
+	}
+
+	if mapping == nil {
+		mapping = make(map[string][]types.EnforcementAction, len(overrides))
+	}
+	for severity, actions := range overrides {
+		mapping[severity] = actions
+	}
+	return mapping, nil
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stig-processor/pkg/extractor"
+	"github.com/stig-processor/pkg/types"
+)
+
+// generateExtractedPolicy builds the FleetPolicy for a group
+// pkg/extractor recognized, once ClassifyGroup has already ruled out a
+// registry check. name identifies which extractor matched (see
+// ProcessingStatistics' per-category counters) and query is the osquery
+// SQL it rendered. Unlike CELCheckGenerator.GenerateCheck, this produces
+// a plain osquery policy (Spec.Query, Language left empty) since every
+// built-in extractor renders native SQL rather than a CEL predicate.
+func (g *FleetPolicyGenerator) generateExtractedPolicy(group *types.STIGGroup, name, query string) (*types.FleetPolicy, error) {
+	policyName := g.sanitizePolicyName(fmt.Sprintf("stig-%s-%s-%s", group.GroupID, group.RuleVersion, name))
+
+	labels := map[string]string{
+		"stig.group_id":     group.GroupID,
+		"stig.rule_version": group.RuleVersion,
+		"stig.severity":     strings.ToLower(group.RuleSeverity),
+		"stig.rule_id":      group.RuleID,
+		"compliance.type":   "stig",
+		"compliance.source": "disa",
+	}
+
+	annotations := map[string]string{
+		"stig.rule_weight":    group.RuleWeight,
+		"stig.rule_ident":     group.RuleIdent,
+		"stig.check_system":   group.RuleCheckSystem,
+		"stig.fix_id":         group.RuleFixID,
+		"extractor.name":      name,
+		"generated.timestamp": time.Now().UTC().Format(time.RFC3339),
+		"generated.tool":      "stig-processor",
+	}
+	for k, v := range g.complianceAnnotations(group) {
+		annotations[k] = v
+	}
+
+	policy := &types.FleetPolicy{
+		APIVersion: types.FleetAPIVersion,
+		Kind:       types.FleetKindPolicy,
+		Metadata: types.PolicyMeta{
+			Name:        policyName,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: types.PolicySpec{
+			Name:        fmt.Sprintf("STIG %s: %s", group.GroupID, group.RuleTitle),
+			Query:       query,
+			Description: g.buildExtractedDescription(group, name, query),
+			Resolution:  g.buildResolutionText(group),
+			Platform:    g.resolvePlatform(group),
+			Critical:    strings.EqualFold(group.RuleSeverity, string(types.SeverityHigh)),
+			Enforcement: g.determineEnforcement(group),
+		},
+	}
+
+	if err := g.validatePolicy(policy); err != nil {
+		return nil, fmt.Errorf("generated extracted policy failed validation: %w", err)
+	}
+
+	return policy, nil
+}
+
+// buildExtractedDescription renders group+query the same way
+// buildPolicyDescription renders a registry-backed policy's description,
+// naming which pkg/extractor extractor produced query in place of the
+// registry check details section that doesn't apply here.
+func (g *FleetPolicyGenerator) buildExtractedDescription(group *types.STIGGroup, name, query string) string {
+	var desc strings.Builder
+
+	desc.WriteString(fmt.Sprintf("STIG Rule %s (Severity: %s)\n\n", group.GroupID, group.RuleSeverity))
+
+	if group.RuleVulnDiscussion != "" {
+		desc.WriteString("Vulnerability Discussion:\n")
+		desc.WriteString(g.formatTextBlock(group.RuleVulnDiscussion))
+		desc.WriteString("\n\n")
+	}
+
+	desc.WriteString("Check Content:\n")
+	desc.WriteString(g.formatTextBlock(group.RuleCheckContent))
+	desc.WriteString("\n\n")
+
+	desc.WriteString(fmt.Sprintf("Extracted by: %s extractor\n", name))
+	desc.WriteString("osquery Query:\n")
+	desc.WriteString(query)
+	desc.WriteString("\n")
+
+	if group.RuleIdent != "" {
+		desc.WriteString(fmt.Sprintf("\nCCI: %s\n", group.RuleIdent))
+	}
+
+	return desc.String()
+}
+
+// tryExtract attempts pkg/extractor.ExtractAll against group's check
+// content, returning the generated policy if some registered extractor
+// recognized it. ok is false (not an error) when none did - the same
+// "needs manual review" signal ParseRegistryCheck and
+// CELCheckGenerator.GenerateCheck give.
+func (g *FleetPolicyGenerator) tryExtract(group *types.STIGGroup) (policy *types.FleetPolicy, ok bool, err error) {
+	name, query, found := extractor.ExtractAll(group.RuleCheckContent)
+	if !found {
+		return nil, false, nil
+	}
+
+	policy, err = g.generateExtractedPolicy(group, name, query)
+	return policy, true, err
+}
@@ -0,0 +1,227 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// PolicyFilename returns the filename WritePolicy uses for policy under
+// format, without marshaling or writing anything - callers that need to
+// know a policy's on-disk name ahead of generating it (e.g.
+// STIGProcessor.ProcessDiff, comparing against a prior run's manifest)
+// can call this instead of duplicating WritePolicy's format switch.
+func PolicyFilename(policy *types.FleetPolicy, format string) string {
+	switch format {
+	case "json":
+		return fmt.Sprintf("%s.json", policy.Metadata.Name)
+	case "sarif":
+		return fmt.Sprintf("%s.sarif.json", policy.Metadata.Name)
+	case "oscal":
+		return fmt.Sprintf("%s.oscal.json", policy.Metadata.Name)
+	case "rego":
+		return fmt.Sprintf("%s.rego", policy.Metadata.Name)
+	default: // yaml
+		return fmt.Sprintf("%s.yaml", policy.Metadata.Name)
+	}
+}
+
+// MarshalSARIF renders policy as a SARIF 2.1.0 log with a single run: one
+// rule (the Fleet policy itself) and one result representing its osquery
+// check, enough structure for GitHub code scanning and similar tooling to
+// ingest it as a finding. It doesn't attempt the rest of the SARIF
+// taxonomy (codeFlows, fixes, suppressions) - one STIG rule maps to one
+// result here, nothing more.
+func MarshalSARIF(policy *types.FleetPolicy, pretty bool) ([]byte, error) {
+	level := "warning"
+	if policy.Spec.Critical {
+		level = "error"
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name: "stig-processor",
+						Rules: []sarifRule{
+							{
+								ID:               policy.Metadata.Name,
+								ShortDescription: sarifText{Text: policy.Spec.Name},
+								FullDescription:  sarifText{Text: policy.Spec.Description},
+								Help:             sarifText{Text: policy.Spec.Resolution},
+							},
+						},
+					},
+				},
+				Results: []sarifResult{
+					{
+						RuleID:  policy.Metadata.Name,
+						Level:   level,
+						Message: sarifText{Text: policy.Spec.Description},
+						Locations: []sarifLocation{
+							{
+								PhysicalLocation: sarifPhysicalLocation{
+									ArtifactLocation: sarifArtifactLocation{URI: policy.Metadata.Name + ".yaml"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if pretty {
+		return json.MarshalIndent(log, "", "  ")
+	}
+	return json.Marshal(log)
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     sarifText              `json:"shortDescription"`
+	FullDescription      sarifText              `json:"fullDescription,omitempty"`
+	Help                 sarifText              `json:"help,omitempty"`
+	HelpURI              string                 `json:"helpUri,omitempty"`
+	DefaultConfiguration *sarifReportingConfig  `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+// sarifReportingConfig is a reportingDescriptor's defaultConfiguration:
+// just the severity level GenerateEvaluationSARIF derives from a
+// policy's Spec.Critical, nothing else in SARIF's configuration taxonomy
+// (enabled, rank, parameters) is used here.
+type sarifReportingConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    sarifText              `json:"message"`
+	Locations  []sarifLocation        `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// MarshalOSCAL renders policy as a minimal NIST OSCAL component-definition
+// JSON document: one component (stig-processor itself) with one control
+// implementation mapping this STIG rule to an implemented requirement,
+// for compliance auditors that consume OSCAL rather than Fleet YAML.
+// Real OSCAL documents identify everything with RFC 4122 UUIDs; nothing
+// in this module vendors a UUID generator, so identifiers here are
+// derived deterministically from the policy name instead of random -
+// stable and unique within one run, but not spec-compliant UUIDs.
+func MarshalOSCAL(policy *types.FleetPolicy, pretty bool) ([]byte, error) {
+	doc := oscalComponentDefinition{
+		ComponentDefinition: oscalComponentDefinitionBody{
+			UUID: "component-definition-" + policy.Metadata.Name,
+			Metadata: oscalMetadata{
+				Title:   fmt.Sprintf("STIG compliance component: %s", policy.Spec.Name),
+				Version: "1.0.0",
+			},
+			Components: []oscalComponent{
+				{
+					UUID:        "component-" + policy.Metadata.Name,
+					Type:        "software",
+					Title:       "stig-processor generated Fleet policy",
+					Description: policy.Spec.Description,
+					ControlImplementations: []oscalControlImplementation{
+						{
+							UUID:        "control-impl-" + policy.Metadata.Name,
+							Source:      "#disa-stig",
+							Description: policy.Spec.Resolution,
+							ImplementedRequirements: []oscalImplementedRequirement{
+								{
+									UUID:        "impl-req-" + policy.Metadata.Name,
+									ControlID:   policy.Metadata.Labels["stig.rule_id"],
+									Description: policy.Spec.Description,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if pretty {
+		return json.MarshalIndent(doc, "", "  ")
+	}
+	return json.Marshal(doc)
+}
+
+type oscalComponentDefinition struct {
+	ComponentDefinition oscalComponentDefinitionBody `json:"component-definition"`
+}
+
+type oscalComponentDefinitionBody struct {
+	UUID       string           `json:"uuid"`
+	Metadata   oscalMetadata    `json:"metadata"`
+	Components []oscalComponent `json:"components"`
+}
+
+type oscalMetadata struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type oscalComponent struct {
+	UUID                   string                       `json:"uuid"`
+	Type                   string                       `json:"type"`
+	Title                  string                       `json:"title"`
+	Description            string                       `json:"description"`
+	ControlImplementations []oscalControlImplementation `json:"control-implementations"`
+}
+
+type oscalControlImplementation struct {
+	UUID                    string                        `json:"uuid"`
+	Source                  string                        `json:"source"`
+	Description             string                        `json:"description"`
+	ImplementedRequirements []oscalImplementedRequirement `json:"implemented-requirements"`
+}
+
+type oscalImplementedRequirement struct {
+	UUID        string `json:"uuid"`
+	ControlID   string `json:"control-id"`
+	Description string `json:"description"`
+}
@@ -1,18 +1,24 @@
 package generator
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/stig-processor/pkg/filters"
 	"github.com/stig-processor/pkg/parser"
+	"github.com/stig-processor/pkg/policyengine"
 	"github.com/stig-processor/pkg/types"
 )
 
@@ -20,15 +26,146 @@ import (
 type FleetPolicyGenerator struct {
 	options   *types.ProcessingOptions
 	regParser *parser.RegistryParser
+	gpParser  *parser.GroupPolicyParser
 	stats     *types.ProcessingStatistics
+
+	// enforcementProfile holds a per-severity EnforcementAction mapping
+	// (see ResolveEnforcementProfile) that resolveScopedEnforcement
+	// consults. Nil unless SetEnforcementProfile has been called, in
+	// which case PolicySpec.ScopedEnforcementActions is left unset.
+	enforcementProfile map[string][]types.EnforcementAction
+
+	// decisions holds pkg/policyengine overrides keyed by GroupID. Nil
+	// unless SetDecisions has been called.
+	decisions map[string]policyengine.RuleDecision
+
+	// filter narrows BatchGenerate to groups matching every non-empty
+	// pkg/filters.FilterSpec dimension, in addition to options.Severity.
+	// Nil (the default) applies no additional filtering. Set with
+	// SetFilter.
+	filter *filters.FilterSpec
+
+	// mapping resolves a group's CCI to NIST 800-53 controls and CIS
+	// benchmarks, for compliance.* policy annotations and filter's
+	// NISTFamilies/CISBenchmarks dimensions. Defaults to pkg/filters'
+	// embedded table; override with SetComplianceMapping.
+	mapping *filters.ComplianceMapping
+
+	// celGen generates a CEL-language policy for a group ClassifyGroup
+	// can't resolve to a registry check, before giving up on it as manual
+	// review. See cel.go.
+	celGen *CELCheckGenerator
+
+	// osqueryCaps gates which osquery tables/columns generated SQL may
+	// reference (see capabilities.go). Defaults to
+	// defaultOsqueryCapabilities(); override with SetOsqueryCapabilities.
+	// options.Strict decides whether a violation is fatal or advisory -
+	// see generateOneWithRecover.
+	osqueryCaps *OsqueryCapabilities
 }
 
 // NewFleetPolicyGenerator creates a new Fleet policy generator
 func NewFleetPolicyGenerator(options *types.ProcessingOptions) *FleetPolicyGenerator {
-	return &FleetPolicyGenerator{
-		options:   options,
-		regParser: parser.NewRegistryParser(options.Verbose),
+	mapping, _ := filters.LoadDefaultMapping()
+	g := &FleetPolicyGenerator{
+		options:     options,
+		regParser:   parser.NewRegistryParser(options.Verbose),
+		gpParser:    parser.NewGroupPolicyParser(options.Verbose),
+		mapping:     mapping,
+		osqueryCaps: defaultOsqueryCapabilities(),
 	}
+	g.celGen = NewCELCheckGenerator(g)
+	return g
+}
+
+// SetDecisions installs policy engine decisions that refine (but, since
+// they still require a parsed registry check, can't by themselves
+// promote) which groups GeneratePolicy treats as automatable, and what
+// platform/tags/remediation text it records for them. Call before
+// BatchGenerate; a nil or empty slice clears any previously set decisions.
+func (g *FleetPolicyGenerator) SetDecisions(decisions []policyengine.RuleDecision) {
+	if len(decisions) == 0 {
+		g.decisions = nil
+		return
+	}
+	g.decisions = make(map[string]policyengine.RuleDecision, len(decisions))
+	for _, d := range decisions {
+		g.decisions[d.GroupID] = d
+	}
+}
+
+// SetEnforcementProfile installs a per-severity EnforcementAction mapping
+// (see ResolveEnforcementProfile) that GeneratePolicy records as each
+// policy's PolicySpec.ScopedEnforcementActions, in addition to the
+// existing Critical/Enforcement fields it always populates. A nil or
+// empty mapping clears it, leaving ScopedEnforcementActions unset.
+func (g *FleetPolicyGenerator) SetEnforcementProfile(profile map[string][]types.EnforcementAction) {
+	if len(profile) == 0 {
+		g.enforcementProfile = nil
+		return
+	}
+	g.enforcementProfile = profile
+}
+
+// SetFilter installs a pkg/filters.FilterSpec that narrows BatchGenerate
+// to matching groups, in addition to options.Severity. A nil spec clears
+// any previously set filter.
+func (g *FleetPolicyGenerator) SetFilter(spec *filters.FilterSpec) {
+	g.filter = spec
+}
+
+// SetComplianceMapping replaces the embedded default CCI-to-NIST/CIS
+// mapping table used for compliance.* policy annotations and filter's
+// NISTFamilies/CISBenchmarks dimensions.
+func (g *FleetPolicyGenerator) SetComplianceMapping(mapping *filters.ComplianceMapping) {
+	g.mapping = mapping
+}
+
+// SetOsqueryCapabilities replaces the default osquery table/column
+// allow-list (registry's path/data columns only) that options.Strict
+// checks generated SQL against. A nil caps clears any previously set
+// capabilities, reverting to defaultOsqueryCapabilities().
+func (g *FleetPolicyGenerator) SetOsqueryCapabilities(caps *OsqueryCapabilities) {
+	if caps == nil {
+		caps = defaultOsqueryCapabilities()
+	}
+	g.osqueryCaps = caps
+}
+
+// CheckOsqueryCapabilities runs checkOsqueryCapabilities and
+// checkOsqueryDeadCode against policy's generated SQL, for any caller
+// that generates a policy outside BatchGenerateWithProgress's worker
+// pool (the streaming pipeline in internal/processor) and still wants
+// options.Strict's osquery validation applied. Returns nil for a
+// non-osquery policy or one with no issues.
+func (g *FleetPolicyGenerator) CheckOsqueryCapabilities(policy *types.FleetPolicy) []string {
+	if policy.Spec.Language != "" && policy.Spec.Language != "osquery" {
+		return nil
+	}
+	var issues []string
+	issues = append(issues, checkOsqueryCapabilities(policy.Spec.Query, g.osqueryCaps)...)
+	issues = append(issues, checkOsqueryDeadCode(policy.Spec.Query)...)
+	return issues
+}
+
+// resolvePlatform returns the platform GeneratePolicy will record for
+// group: a policyengine decision's Platforms, if SetDecisions has named
+// any for this group, else PlatformWindows.
+func (g *FleetPolicyGenerator) resolvePlatform(group *types.STIGGroup) string {
+	if decision, ok := g.decisions[group.GroupID]; ok && len(decision.Platforms) > 0 {
+		return strings.Join(decision.Platforms, ",")
+	}
+	return types.PlatformWindows
+}
+
+// resolveSeverity returns the severity GeneratePolicy records for group:
+// a policyengine decision's Severity, if SetDecisions has set one for
+// this group, else group.RuleSeverity unchanged.
+func (g *FleetPolicyGenerator) resolveSeverity(group *types.STIGGroup) string {
+	if decision, ok := g.decisions[group.GroupID]; ok && decision.Severity != "" {
+		return decision.Severity
+	}
+	return group.RuleSeverity
 }
 
 // GeneratePolicy creates a Fleet policy from a STIG rule and registry checks
@@ -41,20 +178,23 @@ func (g *FleetPolicyGenerator) GeneratePolicy(group *types.STIGGroup, regChecks
 		return nil, fmt.Errorf("registry checks cannot be empty")
 	}
 
-	// Generate osquery SQL
+	// Generate osquery SQL, plus a Rego equivalent for OPA-based evaluation
+	// where osquery isn't available.
 	query := g.regParser.GenerateOsquerySQL(regChecks)
+	rego := g.regParser.GenerateRegoPolicy(group.GroupID, regChecks)
 
 	// Create policy name (sanitized)
 	policyName := g.sanitizePolicyName(fmt.Sprintf("stig-%s-%s", group.GroupID, group.RuleVersion))
 
 	// Determine criticality based on severity
-	critical := strings.EqualFold(group.RuleSeverity, string(types.SeverityHigh))
+	severity := g.resolveSeverity(group)
+	critical := strings.EqualFold(severity, string(types.SeverityHigh))
 
 	// Create labels for better organization
 	labels := map[string]string{
 		"stig.group_id":     group.GroupID,
 		"stig.rule_version": group.RuleVersion,
-		"stig.severity":     strings.ToLower(group.RuleSeverity),
+		"stig.severity":     strings.ToLower(severity),
 		"stig.rule_id":      group.RuleID,
 		"compliance.type":   "stig",
 		"compliance.source": "disa",
@@ -91,9 +231,34 @@ func (g *FleetPolicyGenerator) GeneratePolicy(group *types.STIGGroup, regChecks
 		}
 	}
 
+	// Enrich with cross-framework compliance annotations resolved from
+	// the rule's CCI (group.RuleIdent) via g.mapping.
+	for k, v := range g.complianceAnnotations(group) {
+		annotations[k] = v
+	}
+
 	// Build comprehensive description
 	description := g.buildPolicyDescription(group, regChecks)
 
+	resolution := g.buildResolutionText(group)
+	platform := g.resolvePlatform(group)
+	enforcement := g.determineEnforcement(group)
+	annotations["enforcement.audit"] = strconv.FormatBool(hasEnforcementPoint(enforcement.Points, types.EnforcementPointAudit))
+	annotations["enforcement.runtime"] = strconv.FormatBool(hasEnforcementPoint(enforcement.Points, types.EnforcementPointRuntime))
+	scopedEnforcement := g.resolveScopedEnforcement(severity)
+
+	// A policy engine decision for this group, if any, refines the
+	// tags/remediation text the hardcoded logic above produced (platform
+	// is already folded in by resolvePlatform).
+	if decision, ok := g.decisions[group.GroupID]; ok {
+		if decision.RemediationNotes != "" {
+			resolution = decision.RemediationNotes
+		}
+		if len(decision.Tags) > 0 {
+			annotations["policyengine.tags"] = strings.Join(decision.Tags, ",")
+		}
+	}
+
 	policy := &types.FleetPolicy{
 		APIVersion: types.FleetAPIVersion,
 		Kind:       types.FleetKindPolicy,
@@ -103,12 +268,15 @@ func (g *FleetPolicyGenerator) GeneratePolicy(group *types.STIGGroup, regChecks
 			Annotations: annotations,
 		},
 		Spec: types.PolicySpec{
-			Name:        fmt.Sprintf("STIG %s: %s", group.GroupID, group.RuleTitle),
-			Query:       query,
-			Description: description,
-			Resolution:  g.buildResolutionText(group),
-			Platform:    types.PlatformWindows,
-			Critical:    critical,
+			Name:                     fmt.Sprintf("STIG %s: %s", group.GroupID, group.RuleTitle),
+			Query:                    query,
+			Rego:                     rego,
+			Description:              description,
+			Resolution:               resolution,
+			Platform:                 platform,
+			Critical:                 critical,
+			Enforcement:              enforcement,
+			ScopedEnforcementActions: scopedEnforcement,
 		},
 	}
 
@@ -120,6 +288,38 @@ func (g *FleetPolicyGenerator) GeneratePolicy(group *types.STIGGroup, regChecks
 	return policy, nil
 }
 
+// complianceAnnotations resolves group's cross-framework compliance
+// identifiers via g.mapping into the compliance.* policy annotations -
+// shared by GeneratePolicy and CELCheckGenerator.GenerateCheck so both
+// kinds of generated policy carry the same compliance metadata.
+func (g *FleetPolicyGenerator) complianceAnnotations(group *types.STIGGroup) map[string]string {
+	annotations := make(map[string]string)
+
+	nistControls := g.mapping.ResolveNIST(group.RuleIdent)
+	cisBenchmarks := g.mapping.ResolveCIS(group.RuleIdent)
+	if cis := group.ExternalIDs["cis"]; cis != "" {
+		cisBenchmarks = append(cisBenchmarks, cis)
+	}
+
+	var frameworks []string
+	if group.RuleIdent != "" {
+		frameworks = append(frameworks, "disa-stig")
+	}
+	if len(nistControls) > 0 {
+		annotations["compliance.nist_controls"] = strings.Join(nistControls, ",")
+		frameworks = append(frameworks, "nist-800-53")
+	}
+	if len(cisBenchmarks) > 0 {
+		annotations["compliance.cis_benchmarks"] = strings.Join(cisBenchmarks, ",")
+		frameworks = append(frameworks, "cis")
+	}
+	if len(frameworks) > 0 {
+		annotations["compliance.frameworks"] = strings.Join(frameworks, ",")
+	}
+
+	return annotations
+}
+
 // buildPolicyDescription creates a comprehensive description for the policy
 func (g *FleetPolicyGenerator) buildPolicyDescription(group *types.STIGGroup, regChecks []*types.RegistryCheck) string {
 	var desc strings.Builder
@@ -197,6 +397,64 @@ func (g *FleetPolicyGenerator) buildResolutionText(group *types.STIGGroup) strin
 	return "Refer to STIG documentation for remediation steps."
 }
 
+// determineEnforcement resolves group's EnforcementSpec from
+// options.EnforcementActions (keyed by severity), forcing
+// EnforcementDryRun for any severity listed in options.DryRunSeverities
+// regardless of what the action map says. A severity with no configured
+// action defaults to EnforcementAudit. EnforcementDryRun never carries
+// any enforcement points; every other action applies at the "audit"
+// point, and EnforcementDeny additionally applies at "runtime".
+func (g *FleetPolicyGenerator) determineEnforcement(group *types.STIGGroup) types.EnforcementSpec {
+	severity := strings.ToLower(group.RuleSeverity)
+
+	action := types.EnforcementAudit
+	if configured, ok := g.options.EnforcementActions[severity]; ok && configured != "" {
+		action = configured
+	}
+
+	for _, dryRunSeverity := range g.options.DryRunSeverities {
+		if strings.EqualFold(dryRunSeverity, severity) {
+			action = types.EnforcementDryRun
+			break
+		}
+	}
+
+	var points []string
+	switch action {
+	case types.EnforcementDryRun:
+		// No live enforcement point.
+	case types.EnforcementDeny:
+		points = []string{types.EnforcementPointAudit, types.EnforcementPointRuntime}
+	default: // audit, warn
+		points = []string{types.EnforcementPointAudit}
+	}
+
+	return types.EnforcementSpec{Action: action, Points: points}
+}
+
+// resolveScopedEnforcement looks up severity in g.enforcementProfile,
+// returning the per-point EnforcementAction list PolicySpec records as
+// ScopedEnforcementActions - nil if no profile is installed or severity
+// isn't in it, leaving Critical/Enforcement (from determineEnforcement)
+// as the policy's only enforcement signal, exactly as before this field
+// existed.
+func (g *FleetPolicyGenerator) resolveScopedEnforcement(severity string) []types.EnforcementAction {
+	if g.enforcementProfile == nil {
+		return nil
+	}
+	return g.enforcementProfile[strings.ToLower(severity)]
+}
+
+// hasEnforcementPoint reports whether points contains point.
+func hasEnforcementPoint(points []string, point string) bool {
+	for _, p := range points {
+		if p == point {
+			return true
+		}
+	}
+	return false
+}
+
 // formatTextBlock formats text for better readability
 func (g *FleetPolicyGenerator) formatTextBlock(text string) string {
 	// Clean up the text
@@ -259,9 +517,6 @@ func (g *FleetPolicyGenerator) validatePolicy(policy *types.FleetPolicy) error {
 	if policy.Spec.Name == "" {
 		return fmt.Errorf("spec.name is required")
 	}
-	if policy.Spec.Query == "" {
-		return fmt.Errorf("spec.query is required")
-	}
 
 	// Validate policy name format
 	nameRegex := regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
@@ -269,14 +524,81 @@ func (g *FleetPolicyGenerator) validatePolicy(policy *types.FleetPolicy) error {
 		return fmt.Errorf("invalid policy name format: %s", policy.Metadata.Name)
 	}
 
-	// Validate SQL query (basic checks)
-	if err := g.validateOsquerySQL(policy.Spec.Query); err != nil {
-		return fmt.Errorf("invalid osquery SQL: %w", err)
+	switch policy.Spec.Language {
+	case "", "osquery":
+		if policy.Spec.Query == "" {
+			return fmt.Errorf("spec.query is required")
+		}
+		if err := g.validateOsquerySQL(policy.Spec.Query); err != nil {
+			return fmt.Errorf("invalid osquery SQL: %w", err)
+		}
+	case "cel":
+		if policy.Spec.Expression == "" {
+			return fmt.Errorf("spec.expression is required for a cel policy")
+		}
+		if err := CompileCEL(policy.Spec.Expression); err != nil {
+			return fmt.Errorf("invalid cel expression: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown spec.language: %s", policy.Spec.Language)
+	}
+
+	if err := validateEnforcement(policy.Spec.Enforcement); err != nil {
+		return fmt.Errorf("invalid enforcement: %w", err)
+	}
+
+	for _, action := range policy.Spec.ScopedEnforcementActions {
+		if err := validateScopedEnforcementAction(action); err != nil {
+			return fmt.Errorf("invalid scoped enforcement action: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateEnforcement rejects an unknown EnforcementSpec.Action and
+// enforces that every non-dryrun action names at least one enforcement
+// point - a dryrun action is recorded but never actually applied
+// anywhere, so it's the only one allowed to have none.
+func validateEnforcement(enforcement types.EnforcementSpec) error {
+	valid := false
+	for _, action := range types.ValidEnforcementActions {
+		if enforcement.Action == action {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unknown enforcement action: %s", enforcement.Action)
+	}
+
+	if enforcement.Action != types.EnforcementDryRun && len(enforcement.Points) == 0 {
+		return fmt.Errorf("enforcement action %s requires at least one enforcement point", enforcement.Action)
 	}
 
 	return nil
 }
 
+// validateScopedEnforcementAction rejects an unknown EnforcementAction
+// action or an empty Scope - unlike EnforcementSpec, a scoped action
+// applies at exactly one point, so that point must always be named.
+func validateScopedEnforcementAction(action types.EnforcementAction) error {
+	valid := false
+	for _, a := range types.ValidScopedEnforcementActions {
+		if action.Action == a {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unknown enforcement action: %s", action.Action)
+	}
+	if action.Scope == "" {
+		return fmt.Errorf("scoped enforcement action %s requires a scope", action.Action)
+	}
+	return nil
+}
+
 // validateOsquerySQL performs basic validation on osquery SQL
 func (g *FleetPolicyGenerator) validateOsquerySQL(query string) error {
 	query = strings.TrimSpace(strings.ToLower(query))
@@ -323,7 +645,7 @@ func (g *FleetPolicyGenerator) WritePolicy(policy *types.FleetPolicy, outputDir
 
 	var data []byte
 	var err error
-	var filename string
+	filename := PolicyFilename(policy, g.options.Format)
 
 	switch g.options.Format {
 	case "json":
@@ -332,10 +654,17 @@ func (g *FleetPolicyGenerator) WritePolicy(policy *types.FleetPolicy, outputDir
 		} else {
 			data, err = json.Marshal(policy)
 		}
-		filename = fmt.Sprintf("%s.json", policy.Metadata.Name)
+	case "sarif":
+		data, err = MarshalSARIF(policy, g.options.Pretty)
+	case "oscal":
+		data, err = MarshalOSCAL(policy, g.options.Pretty)
+	case "rego":
+		// policy.Spec.Rego was already generated alongside the osquery
+		// query (see GeneratePolicy); this format just writes it out as
+		// its own standalone .rego file instead of embedding it.
+		data = []byte(policy.Spec.Rego)
 	default: // yaml
 		data, err = yaml.Marshal(policy)
-		filename = fmt.Sprintf("%s.yaml", policy.Metadata.Name)
 	}
 
 	if err != nil {
@@ -354,6 +683,59 @@ func (g *FleetPolicyGenerator) WritePolicy(policy *types.FleetPolicy, outputDir
 	return nil
 }
 
+// fleetPackQuery and kolidePackQuery are the on-disk shapes of one query
+// entry within a Fleet/Kolide osquery pack, keyed by query name in the
+// parent map the same way Fleet's and Kolide's pack formats expect.
+type fleetPackQuery struct {
+	Query       string `yaml:"query" json:"query"`
+	Interval    int    `yaml:"interval" json:"interval"`
+	Platform    string `yaml:"platform" json:"platform"`
+	Description string `yaml:"description" json:"description"`
+}
+
+// FleetPackYAML renders an OsqueryPack as a Fleet query pack YAML document
+// (a top-level "queries" map keyed by query name).
+func FleetPackYAML(pack *parser.OsqueryPack) ([]byte, error) {
+	queries := make(map[string]fleetPackQuery, len(pack.Queries))
+	for _, q := range pack.Queries {
+		queries[q.Name] = fleetPackQuery{
+			Query:       q.Query,
+			Interval:    q.Interval,
+			Platform:    q.Platform,
+			Description: q.Description,
+		}
+	}
+
+	doc := struct {
+		Queries map[string]fleetPackQuery `yaml:"queries"`
+	}{Queries: queries}
+
+	return yaml.Marshal(doc)
+}
+
+// KolidePackJSON renders an OsqueryPack as a Kolide-style osquery pack JSON
+// document (the same "queries" map shape, as plain JSON rather than YAML).
+func KolidePackJSON(pack *parser.OsqueryPack, pretty bool) ([]byte, error) {
+	queries := make(map[string]fleetPackQuery, len(pack.Queries))
+	for _, q := range pack.Queries {
+		queries[q.Name] = fleetPackQuery{
+			Query:       q.Query,
+			Interval:    q.Interval,
+			Platform:    q.Platform,
+			Description: q.Description,
+		}
+	}
+
+	doc := struct {
+		Queries map[string]fleetPackQuery `json:"queries"`
+	}{Queries: queries}
+
+	if pretty {
+		return json.MarshalIndent(doc, "", "  ")
+	}
+	return json.Marshal(doc)
+}
+
 // WriteSummary writes a processing summary file
 func (g *FleetPolicyGenerator) WriteSummary(result *types.ProcessingResult, outputDir string) error {
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
@@ -370,6 +752,9 @@ func (g *FleetPolicyGenerator) WriteSummary(result *types.ProcessingResult, outp
 		Timestamp:         time.Now().UTC().Format(time.RFC3339),
 		Policies:          make([]types.PolicySummaryItem, 0, len(result.Policies)),
 		Errors:            result.Errors,
+		ComplianceRollup:  result.ComplianceRollup,
+		CELAutomatable:    result.CELAutomatable,
+		Warnings:          result.Warnings,
 	}
 
 	// Sort policies by name for consistent output
@@ -378,12 +763,15 @@ func (g *FleetPolicyGenerator) WriteSummary(result *types.ProcessingResult, outp
 	})
 
 	// Add policy summary items
+	enforcementCounts := make(map[string]int)
 	for _, policy := range result.Policies {
 		item := types.PolicySummaryItem{
-			Name:     policy.Metadata.Name,
-			Title:    policy.Spec.Name,
-			Platform: policy.Spec.Platform,
-			Critical: policy.Spec.Critical,
+			Name:        policy.Metadata.Name,
+			Title:       policy.Spec.Name,
+			Platform:    policy.Spec.Platform,
+			Critical:    policy.Spec.Critical,
+			Enforcement: policy.Spec.Enforcement.Action,
+			Language:    policy.Spec.Language,
 		}
 
 		// Extract metadata from labels/annotations
@@ -396,9 +784,20 @@ func (g *FleetPolicyGenerator) WriteSummary(result *types.ProcessingResult, outp
 		if ruleVersion, exists := policy.Metadata.Labels["stig.rule_version"]; exists {
 			item.RuleVersion = ruleVersion
 		}
+		if nistControls, exists := policy.Metadata.Annotations["compliance.nist_controls"]; exists {
+			item.NISTControls = strings.Split(nistControls, ",")
+		}
+		if cisBenchmarks, exists := policy.Metadata.Annotations["compliance.cis_benchmarks"]; exists {
+			item.CISBenchmarks = strings.Split(cisBenchmarks, ",")
+		}
+		if frameworks, exists := policy.Metadata.Annotations["compliance.frameworks"]; exists {
+			item.Frameworks = strings.Split(frameworks, ",")
+		}
 
 		summary.Policies = append(summary.Policies, item)
+		enforcementCounts[item.Enforcement]++
 	}
+	summary.EnforcementCounts = enforcementCounts
 
 	// Marshal and write summary
 	var data []byte
@@ -413,6 +812,13 @@ func (g *FleetPolicyGenerator) WriteSummary(result *types.ProcessingResult, outp
 			data, err = json.Marshal(summary)
 		}
 		filename = "stig-summary.json"
+	case "sarif":
+		// No pass/fail results exist yet at generation time - this is a
+		// reportingDescriptor-only SARIF log (see GenerateEvaluationSARIF),
+		// useful as the rule catalog a later evaluation run's results are
+		// matched against.
+		data, err = GenerateEvaluationSARIF(result.Policies, nil, g.options.Pretty)
+		filename = "stig-summary.sarif.json"
 	default: // yaml
 		data, err = yaml.Marshal(summary)
 		filename = "stig-summary.yaml"
@@ -434,66 +840,257 @@ func (g *FleetPolicyGenerator) WriteSummary(result *types.ProcessingResult, outp
 	return nil
 }
 
-// BatchGenerate generates multiple policies from a list of STIG groups
-func (g *FleetPolicyGenerator) BatchGenerate(groups []types.STIGGroup) *types.ProcessingResult {
+// ClassifyGroup determines whether group's RuleCheckContent parses as an
+// automatable registry check and, if so, generates the FleetPolicy for
+// it. A group that doesn't resolve to a registry check is tried against
+// pkg/extractor's registered extractors (service, file, user, audit,
+// powershell) next, since those render native osquery SQL - the primary
+// Fleet backend - same as a registry check; then against tryGroupPolicy,
+// for a rule whose check text names a Group Policy console setting
+// rather than a raw registry location; only once all three decline does
+// it fall back to g.celGen before being given up on as manual review -
+// see CELCheckGenerator.GenerateCheck. automatable is false only once
+// every stage has declined group, in which case policy and err are both
+// nil.
+// ClassifyGroup does no I/O and mutates no state on g, so it's safe to
+// call concurrently from multiple goroutines over the same generator -
+// the streaming pipeline in internal/processor and
+// BatchGenerateWithProgress's worker pool both do exactly that.
+func (g *FleetPolicyGenerator) ClassifyGroup(group *types.STIGGroup) (policy *types.FleetPolicy, automatable bool, err error) {
+	regChecks, automatable := g.regParser.ParseRegistryCheck(group.RuleCheckContent)
+	if automatable {
+		policy, err = g.GeneratePolicy(group, regChecks)
+		return policy, true, err
+	}
+
+	policy, extracted, err := g.tryExtract(group)
+	if extracted {
+		return policy, true, err
+	}
+
+	policy, gpAutomatable, err := g.tryGroupPolicy(group)
+	if gpAutomatable {
+		return policy, true, err
+	}
+
+	policy, celAutomatable, err := g.celGen.GenerateCheck(group)
+	if celAutomatable {
+		return policy, true, err
+	}
+
+	return nil, false, nil
+}
+
+// tryGroupPolicy resolves group's RuleCheckContent as a Group Policy
+// console setting (see pkg/parser.GroupPolicyParser) against
+// gpParser.ResolveRegistryLocation's curated setting-name table. A
+// setting resolved is rendered as a synthetic types.RegistryCheck, using
+// the STIG rule text's own stated expected value, and handed to
+// GeneratePolicy, so it gets the same osquery SQL rendering, labels, and
+// enforcement logic as a direct registry check. Returns automatable
+// false, with policy and err both nil, if the check text names no known
+// Group Policy setting.
+func (g *FleetPolicyGenerator) tryGroupPolicy(group *types.STIGGroup) (policy *types.FleetPolicy, automatable bool, err error) {
+	checks, ok := g.gpParser.ParseGroupPolicyCheck(group.RuleCheckContent)
+	if !ok {
+		return nil, false, nil
+	}
+
+	for _, check := range checks {
+		hive, path, valueName, resolved := g.gpParser.ResolveRegistryLocation(check.SettingName)
+		if !resolved {
+			continue
+		}
+
+		regCheck := &types.RegistryCheck{
+			Hive:       hive,
+			Path:       path,
+			ValueName:  valueName,
+			Comparison: check.Comparison,
+			Value:      check.ExpectedValue,
+		}
+
+		policy, err := g.GeneratePolicy(group, []*types.RegistryCheck{regCheck})
+		return policy, true, err
+	}
+
+	return nil, false, nil
+}
+
+// BatchGenerate generates multiple policies from a list of STIG groups.
+func (g *FleetPolicyGenerator) BatchGenerate(ctx context.Context, groups []types.STIGGroup) *types.ProcessingResult {
+	return g.BatchGenerateWithProgress(ctx, groups, nil)
+}
+
+// batchOutcome is one group's classify/generate/write result, carried
+// from a generateOneWithRecover worker goroutine back to
+// BatchGenerateWithProgress's single collecting loop over the outcomes
+// channel.
+type batchOutcome struct {
+	group       types.STIGGroup
+	automatable bool
+	policy      *types.FleetPolicy
+	err         error
+	errType     types.ErrorType
+	// warnings holds non-fatal osquery capability/dead-code issues (see
+	// checkOsqueryCapabilities, checkOsqueryDeadCode) found when
+	// options.Strict is false. Populated only alongside a non-nil policy.
+	warnings []string
+}
+
+// BatchGenerateWithProgress fans groups out across options.Concurrency
+// worker goroutines (0 uses runtime.NumCPU()), each running
+// ClassifyGroup (parse the registry check, then GeneratePolicy) and,
+// unless DryRun, WritePolicy - recovering any panic into the group's
+// ProcessingError so one bad rule can't abort the run. WritePolicy calls
+// are bounded by their own options.WriteConcurrency semaphore (0 uses
+// Concurrency), separate from the worker pool, so a slow disk doesn't
+// starve classification/generation. progress, if non-nil, is invoked
+// once per group, in completion order - which, under concurrency, is
+// not input order. result.Policies, result.Errors, and result.Warnings
+// are sorted before returning (by Metadata.Name and GroupID/Message
+// respectively) so the result stays deterministic despite that. A
+// generated policy's osquery SQL that fails checkOsqueryCapabilities or
+// checkOsqueryDeadCode is a fatal ErrorTypeCapabilityViolation (the
+// policy is dropped) when options.Strict is set, or a
+// result.Warnings entry (the policy still ships) otherwise. If options.FailFast is set,
+// no further group starts once any rule has produced a ProcessingError;
+// work already in flight still finishes. ctx cancellation has the same
+// effect.
+func (g *FleetPolicyGenerator) BatchGenerateWithProgress(ctx context.Context, groups []types.STIGGroup, progress func(types.ProcessingEvent)) *types.ProcessingResult {
 	start := time.Now()
 
-	result := &types.ProcessingResult{
-		Total:    len(groups),
-		Policies: make([]types.FleetPolicy, 0),
-		Errors:   make([]types.ProcessingError, 0),
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := g.options.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
 	}
+	writeConcurrency := g.options.WriteConcurrency
+	if writeConcurrency <= 0 {
+		writeConcurrency = concurrency
+	}
+	writeSem := make(chan struct{}, writeConcurrency)
 
+	var eligible []types.STIGGroup
 	for _, group := range groups {
-		// Filter by severity if specified
 		if g.options.Severity != "" && !strings.EqualFold(group.RuleSeverity, g.options.Severity) {
 			continue
 		}
+		if g.filter != nil && !g.filter.Matches(&group, g.resolvePlatform(&group), g.mapping) {
+			continue
+		}
+		eligible = append(eligible, group)
+	}
+
+	jobs := make(chan types.STIGGroup)
+	outcomes := make(chan batchOutcome, len(eligible))
 
-		// Try to parse as registry check
-		regChecks, automatable := g.regParser.ParseRegistryCheck(group.RuleCheckContent)
-		if automatable {
-			result.Automatable++
-
-			// Generate policy
-			policy, err := g.GeneratePolicy(&group, regChecks)
-			if err != nil {
-				result.Errors = append(result.Errors, types.ProcessingError{
-					GroupID:   group.GroupID,
-					RuleID:    group.RuleID,
-					Message:   err.Error(),
-					Type:      types.ErrorTypeValidationFailed,
-					Timestamp: time.Now(),
-				})
-				continue
+	go func() {
+		defer close(jobs)
+		for _, group := range eligible {
+			select {
+			case jobs <- group:
+			case <-runCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range jobs {
+				outcomes <- g.generateOneWithRecover(group, writeSem, progress)
 			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
 
-			result.Policies = append(result.Policies, *policy)
+	result := &types.ProcessingResult{
+		Total:    len(eligible),
+		Policies: make([]types.FleetPolicy, 0, len(eligible)),
+		Errors:   make([]types.ProcessingError, 0),
+	}
+	rollup := make(map[string]*types.FrameworkRollup)
 
-			if g.options.Verbose {
-				fmt.Printf("[AUTOMATABLE] %s: %s\n", group.GroupID, group.RuleTitle)
-			}
+	for outcome := range outcomes {
+		families := filters.ControlFamilies(g.mapping.ResolveNIST(outcome.group.RuleIdent))
 
-			// Write individual policy file if not dry run
-			if !g.options.DryRun {
-				if err := g.WritePolicy(policy, g.options.OutputDir); err != nil {
-					result.Errors = append(result.Errors, types.ProcessingError{
-						GroupID:   group.GroupID,
-						RuleID:    group.RuleID,
-						Message:   fmt.Sprintf("failed to write policy: %v", err),
-						Type:      types.ErrorTypeFileWriteFailed,
-						Timestamp: time.Now(),
-					})
-				}
-			}
-		} else {
+		if !outcome.automatable {
 			result.ManualReview++
+			for _, family := range families {
+				rollupFor(rollup, family).ManualReview++
+			}
 			if g.options.Verbose {
-				fmt.Printf("[MANUAL] %s: %s\n", group.GroupID, group.RuleTitle)
+				fmt.Printf("[MANUAL] %s: %s\n", outcome.group.GroupID, outcome.group.RuleTitle)
+			}
+			continue
+		}
+
+		result.Automatable++
+		for _, family := range families {
+			rollupFor(rollup, family).Automatable++
+		}
+
+		if outcome.err != nil {
+			result.Errors = append(result.Errors, types.ProcessingError{
+				GroupID:   outcome.group.GroupID,
+				RuleID:    outcome.group.RuleID,
+				Message:   outcome.err.Error(),
+				Type:      outcome.errType,
+				Timestamp: time.Now(),
+			})
+			if g.options.FailFast {
+				cancel()
 			}
+			continue
+		}
+
+		result.Policies = append(result.Policies, *outcome.policy)
+		if outcome.policy.Spec.Language == "cel" {
+			result.CELAutomatable++
+		}
+		for _, issue := range outcome.warnings {
+			result.Warnings = append(result.Warnings, types.ProcessingError{
+				GroupID:   outcome.group.GroupID,
+				RuleID:    outcome.group.RuleID,
+				Message:   issue,
+				Type:      types.ErrorTypeCapabilityViolation,
+				Timestamp: time.Now(),
+			})
+		}
+		if g.options.Verbose {
+			fmt.Printf("[AUTOMATABLE] %s: %s\n", outcome.group.GroupID, outcome.group.RuleTitle)
 		}
 	}
 
+	sort.Slice(result.Policies, func(i, j int) bool {
+		return result.Policies[i].Metadata.Name < result.Policies[j].Metadata.Name
+	})
+	sort.Slice(result.Errors, func(i, j int) bool {
+		if result.Errors[i].GroupID != result.Errors[j].GroupID {
+			return result.Errors[i].GroupID < result.Errors[j].GroupID
+		}
+		return result.Errors[i].Message < result.Errors[j].Message
+	})
+	sort.Slice(result.Warnings, func(i, j int) bool {
+		if result.Warnings[i].GroupID != result.Warnings[j].GroupID {
+			return result.Warnings[i].GroupID < result.Warnings[j].GroupID
+		}
+		return result.Warnings[i].Message < result.Warnings[j].Message
+	})
+
+	result.ComplianceRollup = sortedRollup(rollup)
 	result.Duration = time.Since(start)
 
 	// Write summary file if not dry run
@@ -509,3 +1106,105 @@ func (g *FleetPolicyGenerator) BatchGenerate(groups []types.STIGGroup) *types.Pr
 
 	return result
 }
+
+// generateOneWithRecover runs ClassifyGroup and, unless DryRun,
+// WritePolicy (bounded by writeSem) for a single group, recovering any
+// panic into the returned batchOutcome's err instead of propagating it -
+// BatchGenerateWithProgress's per-rule error isolation. emitProgress is
+// called exactly once before returning, win or lose.
+func (g *FleetPolicyGenerator) generateOneWithRecover(group types.STIGGroup, writeSem chan struct{}, progress func(types.ProcessingEvent)) (outcome batchOutcome) {
+	outcome.group = group
+
+	defer func() {
+		if r := recover(); r != nil {
+			outcome.automatable = true
+			outcome.err = fmt.Errorf("panic generating policy: %v", r)
+			outcome.errType = types.ErrorTypeValidationFailed
+			emitProgress(progress, types.EventTypeError, "panic generating policy", group)
+		}
+	}()
+
+	policy, automatable, err := g.ClassifyGroup(&group)
+	outcome.automatable = automatable
+	if !automatable {
+		emitProgress(progress, types.EventTypeInfo, "manual review required", group)
+		return outcome
+	}
+	if err != nil {
+		outcome.err = err
+		outcome.errType = types.ErrorTypeValidationFailed
+		emitProgress(progress, types.EventTypeError, "failed to generate policy", group)
+		return outcome
+	}
+	outcome.policy = policy
+
+	if issues := g.CheckOsqueryCapabilities(policy); len(issues) > 0 {
+		if g.options.Strict {
+			outcome.policy = nil
+			outcome.err = fmt.Errorf("osquery capability violations: %s", strings.Join(issues, "; "))
+			outcome.errType = types.ErrorTypeCapabilityViolation
+			emitProgress(progress, types.EventTypeError, "osquery capability violation", group)
+			return outcome
+		}
+		outcome.warnings = issues
+	}
+
+	if !g.options.DryRun {
+		writeSem <- struct{}{}
+		writeErr := g.WritePolicy(policy, g.options.OutputDir)
+		<-writeSem
+		if writeErr != nil {
+			outcome.err = fmt.Errorf("failed to write policy: %w", writeErr)
+			outcome.errType = types.ErrorTypeFileWriteFailed
+			emitProgress(progress, types.EventTypeError, "failed to write policy", group)
+			return outcome
+		}
+	}
+
+	emitProgress(progress, types.EventTypeSuccess, "generated policy", group)
+	return outcome
+}
+
+// emitProgress calls progress with a ProcessingEvent describing group,
+// if progress is non-nil.
+func emitProgress(progress func(types.ProcessingEvent), eventType types.EventType, message string, group types.STIGGroup) {
+	if progress == nil {
+		return
+	}
+	progress(types.ProcessingEvent{
+		Type:      eventType,
+		Message:   message,
+		GroupID:   group.GroupID,
+		RuleID:    group.RuleID,
+		Timestamp: time.Now(),
+	})
+}
+
+// rollupFor returns rollup's entry for family, creating it if absent.
+func rollupFor(rollup map[string]*types.FrameworkRollup, family string) *types.FrameworkRollup {
+	entry, ok := rollup[family]
+	if !ok {
+		entry = &types.FrameworkRollup{ControlFamily: family}
+		rollup[family] = entry
+	}
+	return entry
+}
+
+// sortedRollup flattens rollup into a slice sorted by control family, for
+// deterministic ProcessingResult/ProcessingSummary output.
+func sortedRollup(rollup map[string]*types.FrameworkRollup) []types.FrameworkRollup {
+	if len(rollup) == 0 {
+		return nil
+	}
+	families := make([]string, 0, len(rollup))
+	for family := range rollup {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+
+	out := make([]types.FrameworkRollup, 0, len(families))
+	for _, family := range families {
+		out = append(out, *rollup[family])
+	}
+	return out
+}
@@ -0,0 +1,272 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+const registryCheckContent = `
+Registry Hive: HKEY_LOCAL_MACHINE
+Registry Path: \SOFTWARE\Policies\Microsoft\Windows\Example
+Value Name: EnableExample
+Value Type: REG_DWORD
+Value: 1
+`
+
+func newTestGenerator(t *testing.T, options *types.ProcessingOptions) *FleetPolicyGenerator {
+	t.Helper()
+	if options == nil {
+		options = &types.ProcessingOptions{}
+	}
+	if options.OutputDir == "" {
+		options.OutputDir = t.TempDir()
+	}
+	return NewFleetPolicyGenerator(options)
+}
+
+func TestClassifyGroupResolvesARegistryCheck(t *testing.T) {
+	g := newTestGenerator(t, nil)
+	group := &types.STIGGroup{
+		GroupID:          "V-1",
+		RuleVersion:      "TEST-000001",
+		RuleTitle:        "Enable Example",
+		RuleSeverity:     "high",
+		RuleCheckContent: registryCheckContent,
+	}
+
+	policy, automatable, err := g.ClassifyGroup(group)
+	if err != nil {
+		t.Fatalf("ClassifyGroup failed: %v", err)
+	}
+	if !automatable {
+		t.Fatal("expected a registry check to be classified as automatable")
+	}
+	if policy.Spec.Language != "" {
+		t.Errorf("got Language %q, want empty (osquery)", policy.Spec.Language)
+	}
+	if policy.Spec.Query == "" {
+		t.Error("expected a non-empty osquery query")
+	}
+}
+
+func TestClassifyGroupFallsBackToCELWhenNoRegistryCheckResolves(t *testing.T) {
+	g := newTestGenerator(t, nil)
+	group := &types.STIGGroup{
+		GroupID:          "V-2",
+		RuleVersion:      "TEST-000002",
+		RuleTitle:        "Disable Telnet",
+		RuleSeverity:     "medium",
+		RuleCheckContent: `Verify the Telnet service is disabled. Run "Get-Service -Name Telnet" and confirm Status is Stopped and StartType is Disabled.`,
+	}
+
+	policy, automatable, err := g.ClassifyGroup(group)
+	if err != nil {
+		t.Fatalf("ClassifyGroup failed: %v", err)
+	}
+	if !automatable {
+		t.Fatal("expected the CEL generator to pick up a service check")
+	}
+	if policy.Spec.Language != "cel" {
+		t.Errorf("got Language %q, want cel", policy.Spec.Language)
+	}
+	if policy.Spec.Expression == "" {
+		t.Error("expected a non-empty CEL expression")
+	}
+}
+
+func TestClassifyGroupReturnsManualReviewWhenNothingResolves(t *testing.T) {
+	g := newTestGenerator(t, nil)
+	group := &types.STIGGroup{
+		GroupID:          "V-3",
+		RuleVersion:      "TEST-000003",
+		RuleTitle:        "Physically secure the server room",
+		RuleSeverity:     "low",
+		RuleCheckContent: "Interview the system administrator and visually confirm the server room door is locked.",
+	}
+
+	policy, automatable, err := g.ClassifyGroup(group)
+	if err != nil {
+		t.Fatalf("ClassifyGroup failed: %v", err)
+	}
+	if automatable {
+		t.Fatal("expected an unrecognized manual check to fall back to manual review")
+	}
+	if policy != nil {
+		t.Errorf("got policy %+v, want nil for manual review", policy)
+	}
+}
+
+func TestResolveScopedEnforcementUsesInstalledProfileBySeverity(t *testing.T) {
+	g := newTestGenerator(t, nil)
+
+	if got := g.resolveScopedEnforcement("high"); got != nil {
+		t.Errorf("got %v, want nil with no enforcement profile installed", got)
+	}
+
+	g.SetEnforcementProfile(map[string][]types.EnforcementAction{
+		"high": {{Action: types.EnforcementDeny, Scope: types.EnforcementPointWebhook}},
+	})
+
+	got := g.resolveScopedEnforcement("HIGH")
+	want := []types.EnforcementAction{{Action: types.EnforcementDeny, Scope: types.EnforcementPointWebhook}}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("got %v, want %v (case-insensitive severity lookup)", got, want)
+	}
+
+	if got := g.resolveScopedEnforcement("medium"); got != nil {
+		t.Errorf("got %v, want nil for a severity absent from the profile", got)
+	}
+
+	g.SetEnforcementProfile(nil)
+	if got := g.resolveScopedEnforcement("high"); got != nil {
+		t.Errorf("got %v, want nil after clearing the profile", got)
+	}
+}
+
+func TestGeneratePolicyRecordsScopedEnforcementActions(t *testing.T) {
+	g := newTestGenerator(t, nil)
+	g.SetEnforcementProfile(map[string][]types.EnforcementAction{
+		"high": {{Action: types.EnforcementDeny, Scope: types.EnforcementPointRuntime}},
+	})
+
+	group := &types.STIGGroup{GroupID: "V-1", RuleVersion: "TEST-000001", RuleTitle: "x", RuleSeverity: "high"}
+	regChecks := []*types.RegistryCheck{{Hive: "HKEY_LOCAL_MACHINE", Path: "x", ValueName: "y", Comparison: "must_exist"}}
+
+	policy, err := g.GeneratePolicy(group, regChecks)
+	if err != nil {
+		t.Fatalf("GeneratePolicy failed: %v", err)
+	}
+	if len(policy.Spec.ScopedEnforcementActions) != 1 || policy.Spec.ScopedEnforcementActions[0].Action != types.EnforcementDeny {
+		t.Errorf("got ScopedEnforcementActions %v, want one deny action", policy.Spec.ScopedEnforcementActions)
+	}
+}
+
+func TestCheckOsqueryCapabilitiesFlagsDisallowedTableAndColumn(t *testing.T) {
+	options := &types.ProcessingOptions{Strict: true}
+	g := newTestGenerator(t, options)
+
+	policy := &types.FleetPolicy{
+		Spec: types.PolicySpec{Query: "SELECT path, data FROM services WHERE name = 'Telnet';"},
+	}
+	issues := g.CheckOsqueryCapabilities(policy)
+	if len(issues) == 0 {
+		t.Fatal("expected issues for a query against an unlisted table")
+	}
+}
+
+func TestCheckOsqueryCapabilitiesAllowsDefaultRegistryQuery(t *testing.T) {
+	g := newTestGenerator(t, nil)
+	policy := &types.FleetPolicy{
+		Spec: types.PolicySpec{Query: "SELECT path, data FROM registry WHERE path = 'x' AND data = 'y';"},
+	}
+	if issues := g.CheckOsqueryCapabilities(policy); len(issues) != 0 {
+		t.Errorf("got issues %v, want none for a query within the default capabilities", issues)
+	}
+}
+
+func TestBatchGenerateWithProgressStrictModeDropsCapabilityViolations(t *testing.T) {
+	options := &types.ProcessingOptions{Strict: true, DryRun: true}
+	g := newTestGenerator(t, options)
+	g.SetOsqueryCapabilities(&OsqueryCapabilities{Tables: map[string][]string{}})
+
+	groups := []types.STIGGroup{
+		{GroupID: "V-1", RuleVersion: "TEST-000001", RuleTitle: "x", RuleSeverity: "high", RuleCheckContent: registryCheckContent},
+	}
+
+	result := g.BatchGenerateWithProgress(context.Background(), groups, nil)
+	if len(result.Policies) != 0 {
+		t.Errorf("got %d policies, want 0 (strict mode should drop the capability violation)", len(result.Policies))
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Type != types.ErrorTypeCapabilityViolation {
+		t.Errorf("got Errors %+v, want one ErrorTypeCapabilityViolation entry", result.Errors)
+	}
+}
+
+func TestBatchGenerateWithProgressNonStrictModeKeepsPolicyAsWarning(t *testing.T) {
+	options := &types.ProcessingOptions{DryRun: true}
+	g := newTestGenerator(t, options)
+	g.SetOsqueryCapabilities(&OsqueryCapabilities{Tables: map[string][]string{}})
+
+	groups := []types.STIGGroup{
+		{GroupID: "V-1", RuleVersion: "TEST-000001", RuleTitle: "x", RuleSeverity: "high", RuleCheckContent: registryCheckContent},
+	}
+
+	result := g.BatchGenerateWithProgress(context.Background(), groups, nil)
+	if len(result.Policies) != 1 {
+		t.Fatalf("got %d policies, want 1 (non-strict mode should keep the policy)", len(result.Policies))
+	}
+	if len(result.Warnings) == 0 {
+		t.Error("got no warnings, want at least one capability-violation warning")
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("got errors %+v, want none", result.Errors)
+	}
+}
+
+// TestBatchGenerateIsDeterministicUnderConcurrency runs the same input
+// many times with a high worker concurrency, confirming the result's
+// Policies/Errors ordering never depends on which goroutine finishes
+// first.
+func TestBatchGenerateIsDeterministicUnderConcurrency(t *testing.T) {
+	var groups []types.STIGGroup
+	for i := 0; i < 20; i++ {
+		severity := "high"
+		content := registryCheckContent
+		if i%3 == 0 {
+			severity = "low"
+			content = "Interview the administrator and confirm compliance by hand."
+		}
+		groups = append(groups, types.STIGGroup{
+			GroupID:          fmt.Sprintf("V-%d", i),
+			RuleVersion:      fmt.Sprintf("TEST-%06d", i),
+			RuleTitle:        fmt.Sprintf("Rule %d", i),
+			RuleSeverity:     severity,
+			RuleCheckContent: content,
+		})
+	}
+
+	options := &types.ProcessingOptions{DryRun: true, Concurrency: 8}
+	g := newTestGenerator(t, options)
+
+	first := g.BatchGenerateWithProgress(context.Background(), groups, nil)
+	for i := 0; i < 5; i++ {
+		result := g.BatchGenerateWithProgress(context.Background(), groups, nil)
+		if len(result.Policies) != len(first.Policies) {
+			t.Fatalf("run %d: got %d policies, want %d", i, len(result.Policies), len(first.Policies))
+		}
+		for j := range first.Policies {
+			if result.Policies[j].Metadata.Name != first.Policies[j].Metadata.Name {
+				t.Errorf("run %d: Policies[%d].Metadata.Name = %q, want %q (ordering should be deterministic)",
+					i, j, result.Policies[j].Metadata.Name, first.Policies[j].Metadata.Name)
+			}
+		}
+	}
+}
+
+// TestBatchGenerateIsolatesErrorsPerGroup confirms one group's
+// classification failure doesn't prevent the rest of the batch from
+// producing policies.
+func TestBatchGenerateIsolatesErrorsPerGroup(t *testing.T) {
+	options := &types.ProcessingOptions{Strict: true, DryRun: true}
+	g := newTestGenerator(t, options)
+	g.SetOsqueryCapabilities(&OsqueryCapabilities{Tables: map[string][]string{}})
+
+	groups := []types.STIGGroup{
+		{GroupID: "V-1", RuleVersion: "TEST-000001", RuleTitle: "bad", RuleSeverity: "high", RuleCheckContent: registryCheckContent},
+		{GroupID: "V-2", RuleVersion: "TEST-000002", RuleTitle: "manual", RuleSeverity: "low", RuleCheckContent: "Interview the administrator."},
+	}
+
+	result := g.BatchGenerateWithProgress(context.Background(), groups, nil)
+	if result.Total != 2 {
+		t.Errorf("got Total %d, want 2", result.Total)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("got %d errors, want 1 (only the capability violation)", len(result.Errors))
+	}
+	if result.ManualReview != 1 {
+		t.Errorf("got ManualReview %d, want 1", result.ManualReview)
+	}
+}
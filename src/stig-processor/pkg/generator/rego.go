@@ -0,0 +1,287 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stig-processor/pkg/parser"
+	"github.com/stig-processor/pkg/types"
+)
+
+// RegoPolicyGenerator is a sibling to FleetPolicyGenerator: instead of
+// wrapping osquery SQL in a Fleet policy YAML, it renders each automatable
+// STIG rule as a standalone OPA Rego module, for consumers (Gatekeeper,
+// Conftest, plain `opa eval`) that evaluate a structured host-state
+// document rather than running osquery. It shares a RegistryParser with
+// FleetPolicyGenerator's GenerateRegoPolicy/regoCondition logic rather
+// than reimplementing rule-body generation.
+type RegoPolicyGenerator struct {
+	options   *types.ProcessingOptions
+	regParser *parser.RegistryParser
+}
+
+// NewRegoPolicyGenerator creates a new Rego bundle generator.
+func NewRegoPolicyGenerator(options *types.ProcessingOptions) *RegoPolicyGenerator {
+	return &RegoPolicyGenerator{
+		options:   options,
+		regParser: parser.NewRegistryParser(options.Verbose),
+	}
+}
+
+// RegoModule is one STIG rule rendered as a Rego package, ready to write
+// to its own file as part of a bundle.
+type RegoModule struct {
+	GroupID     string
+	PackageName string
+	Filename    string
+	Source      string
+	Severity    string
+	Critical    bool
+}
+
+// GenerateModule renders group+regChecks as an OPA Rego module: a
+// "# METADATA" annotation block (title, description, custom.severity,
+// custom.cci) mirroring the labels/annotations
+// FleetPolicyGenerator.GeneratePolicy records for Fleet policies, followed
+// by the compliant/deny rule body parser.RegistryParser.GenerateRegoPolicy
+// already knows how to render.
+func (g *RegoPolicyGenerator) GenerateModule(group *types.STIGGroup, regChecks []*types.RegistryCheck) (*RegoModule, error) {
+	if group == nil {
+		return nil, fmt.Errorf("group cannot be nil")
+	}
+	if len(regChecks) == 0 {
+		return nil, fmt.Errorf("registry checks cannot be empty")
+	}
+
+	body := g.regParser.GenerateRegoPolicy(group.GroupID, regChecks)
+	if body == "" {
+		return nil, fmt.Errorf("failed to render rego body for group %s", group.GroupID)
+	}
+	pkgName := g.regParser.RegoPackageName(group.GroupID)
+
+	var b strings.Builder
+	b.WriteString(metadataBlock(group))
+	b.WriteString(body)
+	source := b.String()
+
+	if err := ValidateRegoModule(source); err != nil {
+		return nil, fmt.Errorf("generated rego module failed validation: %w", err)
+	}
+
+	return &RegoModule{
+		GroupID:     group.GroupID,
+		PackageName: "stig." + pkgName,
+		Filename:    fmt.Sprintf("stig-%s.rego", pkgName),
+		Source:      source,
+		Severity:    strings.ToLower(group.RuleSeverity),
+		Critical:    strings.EqualFold(group.RuleSeverity, string(types.SeverityHigh)),
+	}, nil
+}
+
+// metadataBlock renders an OPA "# METADATA" comment block documenting
+// group, placed directly above the package declaration it annotates.
+func metadataBlock(group *types.STIGGroup) string {
+	var b strings.Builder
+	b.WriteString("# METADATA\n")
+	fmt.Fprintf(&b, "# title: %s\n", commentEscape(group.RuleTitle))
+	fmt.Fprintf(&b, "# description: %s\n", commentEscape(firstLine(group.Description)))
+	b.WriteString("# custom:\n")
+	fmt.Fprintf(&b, "#   severity: %s\n", strings.ToLower(group.RuleSeverity))
+	if group.RuleIdent != "" {
+		fmt.Fprintf(&b, "#   cci: %s\n", group.RuleIdent)
+	}
+	if group.RuleFixText != "" {
+		fmt.Fprintf(&b, "#   fix: %s\n", commentEscape(firstLine(group.RuleFixText)))
+	}
+	return b.String()
+}
+
+// commentEscape strips newlines from text so it can't break out of a
+// single-line "# key: value" METADATA comment.
+func commentEscape(text string) string {
+	text = strings.ReplaceAll(text, "\n", " ")
+	return strings.TrimSpace(text)
+}
+
+// firstLine returns text up to its first newline, for METADATA
+// descriptions built from multi-paragraph STIG vuln discussion text.
+func firstLine(text string) string {
+	if i := strings.Index(text, "\n"); i >= 0 {
+		return text[:i]
+	}
+	return text
+}
+
+// ValidateRegoModule performs basic structural validation on a rendered
+// Rego module, in place of parsing it with a real Rego parser (this
+// codebase doesn't vendor github.com/open-policy-agent/opa/ast; see
+// pkg/policyengine's own hand-rolled compile() for the same tradeoff).
+// It checks the shape GenerateModule always produces: a package
+// declaration, balanced braces, and at least one deny/violation rule.
+func ValidateRegoModule(source string) error {
+	if !strings.Contains(source, "package ") {
+		return fmt.Errorf("module is missing a package declaration")
+	}
+
+	if strings.Count(source, "{") != strings.Count(source, "}") {
+		return fmt.Errorf("module has unbalanced braces")
+	}
+
+	if !strings.Contains(source, "deny[") && !strings.Contains(source, "violation[") {
+		return fmt.Errorf("module has no deny or violation rule")
+	}
+
+	return nil
+}
+
+// BatchGenerateBundle classifies every group the same way
+// FleetPolicyGenerator.BatchGenerate does (regex-parse its check content,
+// skip it if it doesn't resolve to a registry check), but renders each
+// automatable group as a RegoModule instead of a FleetPolicy.
+func (g *RegoPolicyGenerator) BatchGenerateBundle(groups []types.STIGGroup) (*types.ProcessingResult, []*RegoModule) {
+	start := time.Now()
+
+	result := &types.ProcessingResult{
+		Total:  len(groups),
+		Errors: make([]types.ProcessingError, 0),
+	}
+	modules := make([]*RegoModule, 0, len(groups))
+
+	for _, group := range groups {
+		group := group
+		if g.options.Severity != "" && !strings.EqualFold(group.RuleSeverity, g.options.Severity) {
+			continue
+		}
+
+		regChecks, automatable := g.regParser.ParseRegistryCheck(group.RuleCheckContent)
+		if !automatable {
+			result.ManualReview++
+			continue
+		}
+		result.Automatable++
+
+		module, err := g.GenerateModule(&group, regChecks)
+		if err != nil {
+			result.Errors = append(result.Errors, types.ProcessingError{
+				GroupID:   group.GroupID,
+				RuleID:    group.RuleID,
+				Message:   err.Error(),
+				Type:      types.ErrorTypeValidationFailed,
+				Timestamp: time.Now(),
+			})
+			continue
+		}
+
+		modules = append(modules, module)
+	}
+
+	result.Duration = time.Since(start)
+	return result, modules
+}
+
+// bundleManifest is the OPA bundle ".manifest" file's shape: the package
+// roots this bundle is allowed to define, plus a revision identifier so
+// consumers can tell bundles apart across runs.
+type bundleManifest struct {
+	Revision string   `json:"revision"`
+	Roots    []string `json:"roots"`
+}
+
+// ruleManifestEntry is one rule's entry in "data.json", loaded by OPA
+// under data.stig.rules so a deny/violation rule (or a conftest
+// --namespace filter) can look up a failing GroupID's severity/critical
+// flags without re-parsing every module's METADATA comments.
+type ruleManifestEntry struct {
+	Severity string `json:"severity"`
+	Critical bool   `json:"critical"`
+}
+
+// buildRuleManifest indexes modules by GroupID for "data.json", the
+// bundle's companion data document (as distinct from ".manifest", OPA's
+// own bundle-metadata file).
+func buildRuleManifest(modules []*RegoModule) map[string]ruleManifestEntry {
+	rules := make(map[string]ruleManifestEntry, len(modules))
+	for _, module := range modules {
+		rules[module.GroupID] = ruleManifestEntry{
+			Severity: module.Severity,
+			Critical: module.Critical,
+		}
+	}
+	return rules
+}
+
+// WriteBundle writes modules to outputDir as individual .rego files,
+// plus a "main.rego" that imports every module's package and aggregates
+// their deny rules into one data.stig.bundle.deny set, a ".manifest"
+// bundle manifest, and a "data.json" rule-ID-to-severity/critical index
+// (see buildRuleManifest) - so the directory can be loaded directly with
+// `opa eval -b`, `conftest test -p`, or synced as a Gatekeeper bundle.
+func (g *RegoPolicyGenerator) WriteBundle(modules []*RegoModule, outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, module := range modules {
+		path := filepath.Join(outputDir, module.Filename)
+		if err := os.WriteFile(path, []byte(module.Source), 0644); err != nil {
+			return fmt.Errorf("failed to write rego module %s: %w", path, err)
+		}
+	}
+
+	mainPath := filepath.Join(outputDir, "main.rego")
+	if err := os.WriteFile(mainPath, []byte(buildMainModule(modules)), 0644); err != nil {
+		return fmt.Errorf("failed to write main.rego: %w", err)
+	}
+
+	manifest := bundleManifest{
+		Revision: time.Now().UTC().Format(time.RFC3339),
+		Roots:    []string{"stig"},
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle manifest: %w", err)
+	}
+	manifestPath := filepath.Join(outputDir, ".manifest")
+	if err := os.WriteFile(manifestPath, manifestData, 0644); err != nil {
+		return fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+
+	ruleData, err := json.MarshalIndent(struct {
+		Rules map[string]ruleManifestEntry `json:"rules"`
+	}{Rules: buildRuleManifest(modules)}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal rule manifest: %w", err)
+	}
+	rulePath := filepath.Join(outputDir, "data.json")
+	if err := os.WriteFile(rulePath, ruleData, 0644); err != nil {
+		return fmt.Errorf("failed to write rule manifest %s: %w", rulePath, err)
+	}
+
+	return nil
+}
+
+// buildMainModule renders the bundle's entrypoint module: one import per
+// generated package (for consumers that want an explicit package list)
+// plus a deny rule that aggregates every package's deny set under
+// data.stig.bundle.deny.
+func buildMainModule(modules []*RegoModule) string {
+	var b strings.Builder
+	b.WriteString("package stig.bundle\n\n")
+
+	for _, module := range modules {
+		fmt.Fprintf(&b, "import data.%s\n", module.PackageName)
+	}
+
+	b.WriteString("\n# deny aggregates every generated rule's violations into one set, so\n")
+	b.WriteString("# a single data.stig.bundle.deny query covers the whole bundle.\n")
+	b.WriteString("deny[msg] {\n")
+	b.WriteString("\tsome pkg\n")
+	b.WriteString("\tmsg := data.stig[pkg].deny[_]\n")
+	b.WriteString("}\n")
+
+	return b.String()
+}
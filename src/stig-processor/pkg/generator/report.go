@@ -0,0 +1,273 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// RuleResult is one externally observed pass/fail outcome for a
+// generated FleetPolicy's STIG rule - from an osquery/Fleet query result
+// on a live host, or a dry-run against a captured registry snapshot.
+// GenerateEvaluationSARIF and GenerateResultsSummary only render
+// outcomes already observed elsewhere; neither runs any query itself.
+type RuleResult struct {
+	GroupID string `json:"group_id"`
+	Passed  bool   `json:"passed"`
+	// Message overrides the rendered result/summary text; empty uses
+	// the matching policy's Spec.Description (built by GeneratePolicy
+	// from buildPolicyDescription).
+	Message string `json:"message,omitempty"`
+}
+
+// ResultSummaryItem is one policy's evaluation outcome - the shape both
+// GenerateResultsSummary's results.json and ReportSink deal in.
+type ResultSummaryItem struct {
+	ID          string `json:"id"`
+	PolicyName  string `json:"policy_name"`
+	Status      string `json:"status"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Benchmark   string `json:"benchmark"`
+}
+
+// Result statuses a ResultSummaryItem.Status may hold.
+const (
+	ResultStatusPass    = "pass"
+	ResultStatusFail    = "fail"
+	ResultStatusUnknown = "unknown"
+)
+
+// ReportSink is an output destination evaluation results are streamed to
+// one at a time, instead of buffering an entire run's worth of
+// ResultSummaryItems in memory - the point once a quarterly release's
+// several thousand rules are being evaluated. Write is called once per
+// policy, in the order GenerateResultsSummary would return them.
+type ReportSink interface {
+	Write(item ResultSummaryItem) error
+}
+
+// WriterSink is a ReportSink that writes each item as a newline-delimited
+// JSON object to an underlying io.Writer - stdout or an open file, most
+// commonly. A destination that doesn't fit a plain io.Writer (e.g. an
+// HTTP POST per item) implements ReportSink directly instead; it's a
+// single-method interface for exactly that reason.
+type WriterSink struct {
+	w io.Writer
+}
+
+// NewWriterSink wraps w as a ReportSink.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+func (s *WriterSink) Write(item ResultSummaryItem) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result for %s: %w", item.ID, err)
+	}
+	data = append(data, '\n')
+	_, err = s.w.Write(data)
+	return err
+}
+
+// resultSummaryItemFor builds policy's ResultSummaryItem against
+// outcomes (keyed by GroupID, see indexResults). A policy with no
+// matching RuleResult is reported ResultStatusUnknown rather than
+// omitted, so every generated policy is accounted for.
+func resultSummaryItemFor(policy types.FleetPolicy, outcomes map[string]RuleResult) ResultSummaryItem {
+	groupID := policy.Metadata.Labels["stig.group_id"]
+
+	item := ResultSummaryItem{
+		ID:          policy.Metadata.Name,
+		PolicyName:  policy.Spec.Name,
+		Status:      ResultStatusUnknown,
+		Description: policy.Spec.Description,
+		Severity:    policy.Metadata.Labels["stig.severity"],
+		Benchmark:   groupID,
+	}
+
+	outcome, ok := outcomes[groupID]
+	if !ok {
+		return item
+	}
+
+	if outcome.Passed {
+		item.Status = ResultStatusPass
+	} else {
+		item.Status = ResultStatusFail
+	}
+	if outcome.Message != "" {
+		item.Description = outcome.Message
+	}
+
+	return item
+}
+
+// indexResults keys results by GroupID for resultSummaryItemFor's
+// lookups.
+func indexResults(results []RuleResult) map[string]RuleResult {
+	outcomes := make(map[string]RuleResult, len(results))
+	for _, r := range results {
+		outcomes[r.GroupID] = r
+	}
+	return outcomes
+}
+
+// GenerateResultsSummary renders policies and results as the lighter
+// results.json per-policy summary: one ResultSummaryItem per policy, in
+// policies order.
+func GenerateResultsSummary(policies []types.FleetPolicy, results []RuleResult) []ResultSummaryItem {
+	outcomes := indexResults(results)
+
+	items := make([]ResultSummaryItem, 0, len(policies))
+	for _, policy := range policies {
+		items = append(items, resultSummaryItemFor(policy, outcomes))
+	}
+	return items
+}
+
+// WriteResultsSummary marshals GenerateResultsSummary's output as
+// "results.json" in outputDir.
+func (g *FleetPolicyGenerator) WriteResultsSummary(policies []types.FleetPolicy, results []RuleResult, outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	items := GenerateResultsSummary(policies, results)
+
+	var data []byte
+	var err error
+	if g.options.Pretty {
+		data, err = json.MarshalIndent(items, "", "  ")
+	} else {
+		data, err = json.Marshal(items)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal results summary: %w", err)
+	}
+
+	path := filepath.Join(outputDir, "results.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write results summary %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteResultsToSink streams one ResultSummaryItem per policy to sink,
+// in policies order, instead of buffering the full results.json in
+// memory.
+func (g *FleetPolicyGenerator) WriteResultsToSink(policies []types.FleetPolicy, results []RuleResult, sink ReportSink) error {
+	outcomes := indexResults(results)
+
+	for _, policy := range policies {
+		if err := sink.Write(resultSummaryItemFor(policy, outcomes)); err != nil {
+			return fmt.Errorf("failed to write result for %s: %w", policy.Metadata.Name, err)
+		}
+	}
+	return nil
+}
+
+// GenerateEvaluationSARIF renders policies and results as a single
+// aggregate SARIF 2.1.0 log: one reportingDescriptor per policy (ruleId
+// is the STIG GroupID, not the policy name, so findings tie back to the
+// rule rather than one generated artifact), with a DISA help URI and a
+// defaultConfiguration.level derived from severity, and one result per
+// failing RuleResult. Passing or unevaluated rules are described but
+// produce no result - the normal SARIF shape for a clean run. A nil or
+// empty results renders a rule catalog with no results at all (see
+// FleetPolicyGenerator.WriteSummary's "sarif" format branch).
+func GenerateEvaluationSARIF(policies []types.FleetPolicy, results []RuleResult, pretty bool) ([]byte, error) {
+	outcomes := indexResults(results)
+
+	rules := make([]sarifRule, 0, len(policies))
+	var sarifResults []sarifResult
+
+	for _, policy := range policies {
+		groupID := policy.Metadata.Labels["stig.group_id"]
+		level := "warning"
+		if policy.Spec.Critical {
+			level = "error"
+		}
+
+		properties := complianceProperties(policy)
+
+		rules = append(rules, sarifRule{
+			ID:                   groupID,
+			ShortDescription:     sarifText{Text: policy.Spec.Name},
+			FullDescription:      sarifText{Text: policy.Spec.Description},
+			Help:                 sarifText{Text: policy.Spec.Resolution},
+			HelpURI:              disaHelpURI(policy.Metadata.Annotations["stig.rule_ident"]),
+			DefaultConfiguration: &sarifReportingConfig{Level: level},
+			Properties:           properties,
+		})
+
+		outcome, ok := outcomes[groupID]
+		if !ok || outcome.Passed {
+			continue
+		}
+
+		message := outcome.Message
+		if message == "" {
+			message = policy.Spec.Description
+		}
+		sarifResults = append(sarifResults, sarifResult{
+			RuleID:     groupID,
+			Level:      level,
+			Message:    sarifText{Text: message},
+			Properties: properties,
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool:    sarifTool{Driver: sarifDriver{Name: "stig-processor", Rules: rules}},
+				Results: sarifResults,
+			},
+		},
+	}
+
+	if pretty {
+		return json.MarshalIndent(log, "", "  ")
+	}
+	return json.Marshal(log)
+}
+
+// complianceProperties builds a reportingDescriptor/result's SARIF
+// "properties" bag from policy's compliance.* annotations (see
+// FleetPolicyGenerator.GeneratePolicy), carrying the rule's CCI and
+// mapped NIST/CIS identifiers alongside the finding.
+func complianceProperties(policy types.FleetPolicy) map[string]interface{} {
+	properties := make(map[string]interface{})
+	if cci := policy.Metadata.Annotations["stig.rule_ident"]; cci != "" {
+		properties["cci"] = cci
+	}
+	if nist := policy.Metadata.Annotations["compliance.nist_controls"]; nist != "" {
+		properties["nist_controls"] = strings.Split(nist, ",")
+	}
+	if cis := policy.Metadata.Annotations["compliance.cis_benchmarks"]; cis != "" {
+		properties["cis_benchmarks"] = strings.Split(cis, ",")
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return properties
+}
+
+// disaHelpURI builds a best-effort lookup URL for cci on DISA's public
+// CCI reference tool. Like MarshalOSCAL's synthesized identifiers, it's
+// deterministic from input data, not a verified live link.
+func disaHelpURI(cci string) string {
+	if cci == "" {
+		return ""
+	}
+	return "https://public.cyber.mil/stigs/cci/?s=" + cci
+}
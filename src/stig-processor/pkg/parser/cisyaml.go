@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// cisBenchmark is the normalized shape this tool expects from a flattened
+// CIS benchmark YAML export: a title/version header plus a flat list of
+// recommendations. Real CIS exports nest recommendations under sections;
+// producing this flat file from the official workbook is out of scope here.
+type cisBenchmark struct {
+	Title           string              `yaml:"title"`
+	Version         string              `yaml:"version"`
+	Recommendations []cisRecommendation `yaml:"recommendations"`
+}
+
+type cisRecommendation struct {
+	ID          string `yaml:"id"`          // e.g. "1.1.1"
+	Title       string `yaml:"title"`
+	Severity    string `yaml:"severity"`    // CIS uses "Scored"/"Not Scored"; mapped to high/medium/low below
+	Description string `yaml:"description"`
+	Rationale   string `yaml:"rationale"`
+	Remediation string `yaml:"remediation"`
+	AuditText   string `yaml:"audit"`
+}
+
+// ParseCISYAML loads a flattened CIS benchmark YAML file and normalizes it
+// into a types.STIGBenchmark so the rest of the pipeline (registry
+// extraction, osquery generation, statistics) works unchanged. Each
+// recommendation's CIS ID is preserved in STIGGroup.ExternalIDs["cis"].
+func (p *STIGParser) ParseCISYAML(filePath string) (*types.STIGBenchmark, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	var cis cisBenchmark
+	if err := yaml.Unmarshal(data, &cis); err != nil {
+		return nil, fmt.Errorf("failed to parse CIS YAML: %w", err)
+	}
+
+	stig := &types.STIGBenchmark{
+		Title:     cis.Title,
+		Version:   cis.Version,
+		Framework: "cis-yaml",
+	}
+
+	for _, rec := range cis.Recommendations {
+		stig.Groups = append(stig.Groups, types.STIGGroup{
+			GroupID:            fmt.Sprintf("CIS-%s", rec.ID),
+			Title:              rec.Title,
+			RuleVersion:        rec.ID,
+			RuleSeverity:       cisSeverity(rec.Severity),
+			RuleTitle:          rec.Title,
+			RuleVulnDiscussion: rec.Rationale,
+			RuleFixText:        rec.Remediation,
+			RuleCheckContent:   rec.AuditText,
+			ExternalIDs:        map[string]string{"cis": rec.ID},
+		})
+	}
+
+	if p.verbose {
+		fmt.Printf("Parsed CIS benchmark: %s v%s with %d recommendations\n", stig.Title, stig.Version, len(stig.Groups))
+	}
+
+	return stig, nil
+}
+
+// cisSeverity maps CIS's Scored/Not Scored designation onto this tool's
+// high/medium/low severity scale. CIS doesn't have a direct equivalent, so
+// scored (automatable) controls are treated as medium and everything else
+// as low until a real mapping table is supplied.
+func cisSeverity(scoring string) string {
+	if scoring == "Scored" {
+		return string(types.SeverityMedium)
+	}
+	return string(types.SeverityLow)
+}
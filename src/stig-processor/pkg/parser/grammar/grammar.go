@@ -0,0 +1,188 @@
+// Package grammar implements a small hand-written lexer/parser for the
+// "Registry Hive:" / "Registry Path:" / "Value Name:" / "Value Type:" /
+// "Value:" stanzas that appear in DISA STIG check-content text, plus the
+// surrounding finding sentence ("this is a finding", "or greater", "or
+// less", "must not exist"). It replaces the loose top-level regexes in
+// parser.RegistryParser with an explicit AST so the long tail of STIG
+// phrasing (multi-value expected data, nested alternatives, REG_MULTI_SZ
+// lists) can be handled in one place instead of by regex tweaking.
+package grammar
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrNoRegistryStanza is returned by Parse when checkContent contains no
+// "Registry Hive:" stanza at all, i.e. the rule isn't a registry check.
+var ErrNoRegistryStanza = errors.New("check content does not contain a Registry Hive stanza")
+
+// Polarity describes whether the surrounding sentence asserts the finding
+// when the condition holds or when it does not.
+type Polarity int
+
+const (
+	// PolarityFindingIfFails is the common STIG phrasing: "If ... is not
+	// configured ..., this is a finding" — compliant means the condition holds.
+	PolarityFindingIfFails Polarity = iota
+	// PolarityFindingIfHolds is the inverse phrasing: "If ... exists ...,
+	// this is a finding" — compliant means the condition does NOT hold.
+	PolarityFindingIfHolds
+)
+
+// Condition is a single expected-value comparison extracted from a check block.
+type Condition struct {
+	Comparison string   // "equals", "greater_equal", "less_equal", "not_exists", "must_exist"
+	Values     []string // one entry for a scalar comparison, many for a REG_MULTI_SZ list or an "or" alternative
+}
+
+// CheckBlock is the parsed representation of one "Registry Hive: ..." stanza.
+type CheckBlock struct {
+	Hive      string
+	Path      string
+	ValueName string
+	ValueType string
+	Target    string // "Hive\Path\ValueName", kept for callers that want the full key
+	Condition Condition
+	Polarity  Polarity
+}
+
+var (
+	hiveRe       = regexp.MustCompile(`Registry Hive:\s*(HKEY_[A-Z_]+)`)
+	pathRe       = regexp.MustCompile(`Registry Path:\s*\\?(.+?)\s*(?:\n|$)`)
+	nameRe       = regexp.MustCompile(`Value Name:\s*(.+?)\s*(?:\n|$)`)
+	typeRe       = regexp.MustCompile(`(?:Value Type|Type):\s*(REG_[A-Z_]+)`)
+	valueRe      = regexp.MustCompile(`Value:\s*(.+?)\s*(?:\n|$)`)
+	hexWithDecRe = regexp.MustCompile(`0x[0-9a-fA-F]+\s*\((\d+)\)`)
+)
+
+// Parse tokenizes checkContent into a slice of CheckBlock values, one per
+// "Registry Hive:" stanza found in the text. It returns an error only when
+// the content contains no registry stanza at all, so callers can tell
+// "not a registry check" apart from "registry check we failed to parse".
+func Parse(checkContent string) ([]CheckBlock, error) {
+	hiveMatches := hiveRe.FindAllStringSubmatchIndex(checkContent, -1)
+	if len(hiveMatches) == 0 {
+		return nil, ErrNoRegistryStanza
+	}
+
+	var blocks []CheckBlock
+	for i, m := range hiveMatches {
+		start := m[0]
+		end := len(checkContent)
+		if i+1 < len(hiveMatches) {
+			end = hiveMatches[i+1][0]
+		}
+		section := checkContent[start:end]
+
+		block, ok := parseSection(checkContent[m[2]:m[3]], section)
+		if ok {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks, nil
+}
+
+func parseSection(hive, section string) (CheckBlock, bool) {
+	pathMatch := pathRe.FindStringSubmatch(section)
+	nameMatch := nameRe.FindStringSubmatch(section)
+	if pathMatch == nil || nameMatch == nil {
+		return CheckBlock{}, false
+	}
+
+	path := strings.TrimSuffix(strings.TrimSpace(pathMatch[1]), "\\")
+	valueName := strings.TrimSpace(nameMatch[1])
+
+	valueType := "REG_DWORD"
+	if tm := typeRe.FindStringSubmatch(section); tm != nil {
+		valueType = strings.TrimSpace(tm[1])
+	}
+
+	rawValue := ""
+	if vm := valueRe.FindStringSubmatch(section); vm != nil {
+		rawValue = strings.TrimSpace(vm[1])
+	}
+
+	condition := parseCondition(section, rawValue, valueType)
+
+	block := CheckBlock{
+		Hive:      hive,
+		Path:      path,
+		ValueName: valueName,
+		ValueType: valueType,
+		Target:    hive + `\` + path + `\` + valueName,
+		Condition: condition,
+		Polarity:  parsePolarity(section),
+	}
+
+	return block, true
+}
+
+// parseCondition determines the comparison operator and the (possibly
+// multi-valued) expected data from the value literal and the surrounding
+// sentence, handling REG_MULTI_SZ lists ("a, b, c" or "a or b") as well as
+// the scalar case the legacy regex parser supported.
+func parseCondition(section, rawValue, valueType string) Condition {
+	comparison := "equals"
+	sectionLower := strings.ToLower(section)
+
+	switch {
+	case strings.Contains(sectionLower, "or greater") || strings.Contains(section, ">="):
+		comparison = "greater_equal"
+	case strings.Contains(sectionLower, "or fewer") || strings.Contains(sectionLower, "or less") || strings.Contains(section, "<="):
+		comparison = "less_equal"
+	case strings.Contains(sectionLower, "must not exist") || strings.Contains(sectionLower, "should not exist"):
+		comparison = "not_exists"
+	case (strings.Contains(sectionLower, "must exist") || strings.Contains(sectionLower, "should exist")) &&
+		!strings.Contains(sectionLower, "does not exist"):
+		comparison = "must_exist"
+	}
+
+	values := splitExpectedValues(rawValue, valueType)
+	for i, v := range values {
+		if hm := hexWithDecRe.FindStringSubmatch(v); len(hm) >= 2 {
+			values[i] = hm[1]
+		}
+	}
+
+	return Condition{Comparison: comparison, Values: values}
+}
+
+// splitExpectedValues splits a REG_MULTI_SZ expected-data literal (which
+// STIG authors render as a comma list or an "or" alternative) into its
+// individual values. Scalar types return a single-element slice.
+func splitExpectedValues(raw, valueType string) []string {
+	if raw == "" {
+		return nil
+	}
+	if valueType != "REG_MULTI_SZ" {
+		return []string{raw}
+	}
+
+	sep := ","
+	if strings.Contains(raw, " or ") && !strings.Contains(raw, ",") {
+		sep = " or "
+	}
+
+	var values []string
+	for _, part := range strings.Split(raw, sep) {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+func parsePolarity(section string) Polarity {
+	sectionLower := strings.ToLower(section)
+	// "If the ... exists ..., this is a finding" inverts the usual polarity.
+	if strings.Contains(sectionLower, "exists") && strings.Contains(sectionLower, "this is a finding") &&
+		strings.Contains(sectionLower, "if the") && !strings.Contains(sectionLower, "does not exist") &&
+		!strings.Contains(sectionLower, "is not configured") {
+		return PolarityFindingIfHolds
+	}
+	return PolarityFindingIfFails
+}
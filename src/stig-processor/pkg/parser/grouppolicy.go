@@ -0,0 +1,168 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// GroupPolicyParser extracts Group Policy settings from STIG check content,
+// mirroring RegistryParser's shape for the "Computer Configuration >> ... >>
+// Setting Name: <value>" stanzas that registry-only parsing misses.
+type GroupPolicyParser struct {
+	verbose bool
+
+	breadcrumbRegex *regexp.Regexp
+	settingRegex    *regexp.Regexp
+}
+
+// NewGroupPolicyParser creates a new Group Policy parser.
+func NewGroupPolicyParser(verbose bool) *GroupPolicyParser {
+	return &GroupPolicyParser{
+		verbose:         verbose,
+		breadcrumbRegex: regexp.MustCompile(`(Computer|User) Configuration\s*(?:>>\s*[^\n>]+)*`),
+		settingRegex:    regexp.MustCompile(`Setting Name:\s*(.+?)\s*(?:\n|$)[\s\S]*?Setting State:\s*(.+?)\s*(?:\n|$)`),
+	}
+}
+
+// ParseGroupPolicyCheck extracts Group Policy check information from STIG
+// rule check content.
+func (gp *GroupPolicyParser) ParseGroupPolicyCheck(checkContent string) ([]*types.GroupPolicyCheck, bool) {
+	breadcrumbMatches := gp.breadcrumbRegex.FindAllStringSubmatchIndex(checkContent, -1)
+	if len(breadcrumbMatches) == 0 {
+		return nil, false
+	}
+
+	var checks []*types.GroupPolicyCheck
+
+	for i, m := range breadcrumbMatches {
+		start := m[0]
+		end := len(checkContent)
+		if i+1 < len(breadcrumbMatches) {
+			end = breadcrumbMatches[i+1][0]
+		}
+		section := checkContent[start:end]
+
+		scope := checkContent[m[2]:m[3]] + " Configuration"
+		breadcrumb := checkContent[m[0]:m[1]]
+		path := gp.parseBreadcrumb(breadcrumb)
+
+		settingMatch := gp.settingRegex.FindStringSubmatch(section)
+		if settingMatch == nil {
+			if gp.verbose {
+				fmt.Printf("  No Setting Name/State found under %s\n", scope)
+			}
+			continue
+		}
+
+		settingName := strings.TrimSpace(settingMatch[1])
+		state := strings.TrimSpace(settingMatch[2])
+
+		comparison, expected := gp.parseSettingState(state)
+
+		checks = append(checks, &types.GroupPolicyCheck{
+			Scope:         scope,
+			Path:          path,
+			SettingName:   settingName,
+			ExpectedValue: expected,
+			Comparison:    comparison,
+		})
+	}
+
+	return checks, len(checks) > 0
+}
+
+// parseBreadcrumb splits a "Computer Configuration >> Administrative
+// Templates >> Windows Components >> ..." breadcrumb into its path
+// segments, dropping the leading scope.
+func (gp *GroupPolicyParser) parseBreadcrumb(breadcrumb string) []string {
+	parts := strings.Split(breadcrumb, ">>")
+	var path []string
+	for i, part := range parts {
+		if i == 0 {
+			continue // scope segment, already captured separately
+		}
+		part = strings.TrimSpace(part)
+		if part != "" {
+			path = append(path, part)
+		}
+	}
+	return path
+}
+
+// parseSettingState turns a GPO "Setting State:" value (e.g. "Enabled",
+// "Disabled", "Not Configured") into a RegistryCheck-style comparison.
+func (gp *GroupPolicyParser) parseSettingState(state string) (comparison, expected string) {
+	switch strings.ToLower(state) {
+	case "enabled":
+		return "equals", "1"
+	case "disabled":
+		return "equals", "0"
+	case "not configured":
+		return "not_exists", ""
+	default:
+		return "equals", state
+	}
+}
+
+// gpoRegistryMapping is a curated table of well-known Administrative
+// Template policy names to the registry location they back. It is far from
+// exhaustive but covers the common Windows 11 STIG settings so the most
+// frequently seen GP checks become automatable via the registry table.
+var gpoRegistryMapping = map[string]struct {
+	hive, path, valueName string
+}{
+	"Configure Windows Defender SmartScreen": {
+		types.HKeyLocalMachine, `SOFTWARE\Policies\Microsoft\Windows\System`, "EnableSmartScreen",
+	},
+	"Turn off Autoplay": {
+		types.HKeyLocalMachine, `SOFTWARE\Policies\Microsoft\Windows\Explorer`, "NoAutoplayfornonVolume",
+	},
+	"Allow Telemetry": {
+		types.HKeyLocalMachine, `SOFTWARE\Policies\Microsoft\Windows\DataCollection`, "AllowTelemetry",
+	},
+	"Turn off Microsoft consumer experiences": {
+		types.HKeyLocalMachine, `SOFTWARE\Policies\Microsoft\Windows\CloudContent`, "DisableWindowsConsumerFeatures",
+	},
+	"Configure SMB v1 client driver": {
+		types.HKeyLocalMachine, `SYSTEM\CurrentControlSet\Services\mrxsmb10`, "Start",
+	},
+}
+
+// ResolveRegistryLocation looks up settingName in gpoRegistryMapping, the
+// curated table of well-known Administrative Template console names to
+// the registry value they back. ok is false if settingName isn't in the
+// table. GenerateOsquerySQL below reads the same table directly; this
+// lookup is used by pkg/generator's GPO
+// resolution path (see pkg/gpo), which additionally checks a real parsed
+// Registry.pol against the same hive/path/valueName rather than trusting
+// only the STIG rule text's own stated expected value.
+func (gp *GroupPolicyParser) ResolveRegistryLocation(settingName string) (hive, path, valueName string, ok bool) {
+	m, found := gpoRegistryMapping[settingName]
+	if !found {
+		return "", "", "", false
+	}
+	return m.hive, m.path, m.valueName, true
+}
+
+// GenerateOsquerySQL translates a Group Policy check into osquery SQL
+// against the registry table, resolving the setting name against the
+// curated gpoRegistryMapping. Returns "" if the setting has no known
+// registry backing.
+func (gp *GroupPolicyParser) GenerateOsquerySQL(check *types.GroupPolicyCheck) string {
+	mapping, ok := gpoRegistryMapping[check.SettingName]
+	if !ok {
+		return ""
+	}
+
+	fullPath := fmt.Sprintf(`%s\%s\%s`, mapping.hive, mapping.path, mapping.valueName)
+
+	if check.Comparison == "not_exists" {
+		return fmt.Sprintf("SELECT 1 WHERE NOT EXISTS (SELECT 1 FROM registry WHERE path = '%s');", fullPath)
+	}
+
+	escaped := strings.ReplaceAll(check.ExpectedValue, "'", "''")
+	return fmt.Sprintf("SELECT 1 FROM registry WHERE path = '%s' AND data = '%s';", fullPath, escaped)
+}
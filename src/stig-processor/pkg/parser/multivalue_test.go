@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// multiValueCheckContent describes a REG_MULTI_SZ condition with three
+// acceptable alternatives, the shape chunk0-2 was asked to fix: a host
+// matching the second or third alternative is compliant, not a finding.
+const multiValueCheckContent = `
+Registry Hive: HKEY_LOCAL_MACHINE
+Registry Path: \SOFTWARE\Policies\Microsoft\Windows\Example
+Value Name: AllowedSigners
+Value Type: REG_MULTI_SZ
+Value: Alice, Bob, Carol
+
+If the value is not one of the above, this is a finding.
+`
+
+func TestParseRegistryCheckKeepsAllMultiValueAlternatives(t *testing.T) {
+	rp := NewRegistryParser(false)
+
+	checks, ok := rp.ParseRegistryCheck(multiValueCheckContent)
+	if !ok || len(checks) != 1 {
+		t.Fatalf("expected exactly one registry check, got %d (ok=%v)", len(checks), ok)
+	}
+
+	check := checks[0]
+	want := []string{"Alice", "Bob", "Carol"}
+	if len(check.Values) != len(want) {
+		t.Fatalf("Values = %v, want %v", check.Values, want)
+	}
+	for i, v := range want {
+		if check.Values[i] != v {
+			t.Errorf("Values[%d] = %q, want %q", i, check.Values[i], v)
+		}
+	}
+	if check.Value != "Alice" {
+		t.Errorf("Value = %q, want first alternative %q (back-compat)", check.Value, "Alice")
+	}
+}
+
+func TestGenerateOsquerySQLMatchesAnyAlternative(t *testing.T) {
+	rp := NewRegistryParser(false)
+
+	// REG_SZ with multiple acceptable values, as populated by the grammar
+	// parser for a REG_MULTI_SZ/"or"-alternative condition - built directly
+	// here since the osquery registry table's REG_MULTI_SZ handling already
+	// checks presence rather than a specific value, independent of this fix.
+	check := &types.RegistryCheck{
+		Hive:       "HKEY_LOCAL_MACHINE",
+		Path:       `SOFTWARE\Policies\Microsoft\Windows\Example`,
+		ValueName:  "AllowedSigner",
+		ValueType:  types.RegSZ,
+		Value:      "Alice",
+		Values:     []string{"Alice", "Bob", "Carol"},
+		Comparison: "equals",
+	}
+
+	sql := rp.GenerateOsquerySQL([]*types.RegistryCheck{check})
+	for _, v := range []string{"Bob", "Carol"} {
+		if !strings.Contains(sql, "data = '"+v+"'") {
+			t.Errorf("generated SQL %q does not check alternative %q; a host matching it would be a false-positive finding", sql, v)
+		}
+	}
+}
+
+func TestGenerateRegoPolicyMatchesAnyAlternative(t *testing.T) {
+	rp := NewRegistryParser(false)
+
+	checks, ok := rp.ParseRegistryCheck(multiValueCheckContent)
+	if !ok || len(checks) != 1 {
+		t.Fatalf("expected exactly one registry check, got %d (ok=%v)", len(checks), ok)
+	}
+
+	module := rp.GenerateRegoPolicy("example-rule", checks)
+	for _, v := range []string{"\"Bob\"", "\"Carol\""} {
+		if !strings.Contains(module, v) {
+			t.Errorf("generated Rego module %q does not reference alternative %s", module, v)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// OsqueryQuery is a single named osquery query targeting one RegistryCheck,
+// replacing the old approach of folding every condition into one monolithic
+// WHERE clause (which made it impossible to tell which sub-check failed).
+type OsqueryQuery struct {
+	Name        string // stable name, e.g. "stig_wn11-00-000001_0"
+	Query       string
+	Platform    string
+	Description string
+	Interval    int // seconds
+}
+
+// OsqueryPack is the structured, per-check output of GenerateOsqueryPack: one
+// OsqueryQuery per RegistryCheck plus a combined ComplianceQuery that UNIONs
+// them together so a single query tells the caller which condition(s) failed.
+type OsqueryPack struct {
+	RuleID          string
+	Queries         []OsqueryQuery
+	ComplianceQuery string
+}
+
+const defaultQueryInterval = 3600
+
+// GenerateOsqueryPack builds a structured OsqueryPack from a set of registry
+// checks: one stable-named query per check (so results can be attributed to
+// the specific condition that failed), plus a single UNION ALL view that
+// reports pass/fail per condition in one query. Expected values are bound as
+// quoted SQL string literals produced by sqlQuote rather than ad-hoc
+// ReplaceAll escaping, and every generated statement is run through
+// ValidateOsquerySQL before being returned.
+func (rp *RegistryParser) GenerateOsqueryPack(ruleID string, regChecks []*types.RegistryCheck) (*OsqueryPack, error) {
+	pack := &OsqueryPack{RuleID: ruleID}
+
+	var unionParts []string
+	for i, check := range regChecks {
+		name := fmt.Sprintf("stig_%s_%d", sanitizeQueryName(ruleID), i)
+		query := rp.singleCheckQuery(check)
+
+		if err := ValidateOsquerySQL(query); err != nil {
+			return nil, fmt.Errorf("generated query %s is invalid: %w", name, err)
+		}
+
+		pack.Queries = append(pack.Queries, OsqueryQuery{
+			Name:        name,
+			Query:       query,
+			Platform:    "windows",
+			Description: fmt.Sprintf("%s check %d/%d: %s\\%s", ruleID, i+1, len(regChecks), check.Hive, check.ValueName),
+			Interval:    defaultQueryInterval,
+		})
+
+		unionParts = append(unionParts, rp.unionSelect(name, check))
+	}
+
+	pack.ComplianceQuery = strings.Join(unionParts, "\nUNION ALL\n") + ";"
+
+	if err := ValidateOsquerySQL(strings.TrimSuffix(pack.ComplianceQuery, ";")); err != nil {
+		return nil, fmt.Errorf("generated compliance view is invalid: %w", err)
+	}
+
+	return pack, nil
+}
+
+// singleCheckQuery builds the query for one RegistryCheck using sqlQuote for
+// every interpolated value instead of ad-hoc escaping.
+func (rp *RegistryParser) singleCheckQuery(check *types.RegistryCheck) string {
+	fullPath := fmt.Sprintf(`%s\%s\%s`, check.Hive, check.Path, check.ValueName)
+
+	switch check.Comparison {
+	case "not_exists":
+		return fmt.Sprintf("SELECT 1 WHERE NOT EXISTS (SELECT 1 FROM registry WHERE path = %s)", sqlQuote(fullPath))
+	case "must_exist":
+		return fmt.Sprintf("SELECT 1 FROM registry WHERE path = %s", sqlQuote(fullPath))
+	case "greater_equal":
+		return fmt.Sprintf("SELECT 1 FROM registry WHERE path = %s AND CAST(data AS INTEGER) >= %s", sqlQuote(fullPath), check.Value)
+	case "less_equal":
+		return fmt.Sprintf("SELECT 1 FROM registry WHERE path = %s AND CAST(data AS INTEGER) <= %s", sqlQuote(fullPath), check.Value)
+	default:
+		return fmt.Sprintf("SELECT 1 FROM registry WHERE path = %s AND %s", sqlQuote(fullPath), dataEqualsAnyQuoted(check))
+	}
+}
+
+// dataEqualsAnyQuoted renders an equality condition against every value
+// check.Values accepts (falling back to check.Value alone when there's
+// only one), joined with OR via sqlQuote, so a REG_MULTI_SZ/"or"-alternative
+// condition matches a compliant host whose registry value is any one of
+// the acceptable alternatives rather than only the first one.
+func dataEqualsAnyQuoted(check *types.RegistryCheck) string {
+	values := check.Values
+	if len(values) == 0 {
+		values = []string{check.Value}
+	}
+
+	clauses := make([]string, len(values))
+	for i, v := range values {
+		clauses[i] = fmt.Sprintf("data = %s", sqlQuote(v))
+	}
+
+	if len(clauses) == 1 {
+		return clauses[0]
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")"
+}
+
+// unionSelect wraps a single query's result as one row of the combined
+// compliance view, labeling which registry path/condition it evaluated.
+func (rp *RegistryParser) unionSelect(name string, check *types.RegistryCheck) string {
+	fullPath := fmt.Sprintf(`%s\%s\%s`, check.Hive, check.Path, check.ValueName)
+	return fmt.Sprintf(
+		"SELECT %s AS query_name, %s AS path, %s AS comparison, EXISTS(%s) AS passed",
+		sqlQuote(name), sqlQuote(fullPath), sqlQuote(check.Comparison), rp.singleCheckQuery(check),
+	)
+}
+
+// sqlQuote renders a Go string as a single-quoted SQL literal, escaping
+// embedded single quotes by doubling them (the standard SQL escape).
+func sqlQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// sanitizeQueryName lowercases and strips characters that aren't safe in an
+// osquery pack query name.
+func sanitizeQueryName(ruleID string) string {
+	return strings.ToLower(strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, ruleID))
+}
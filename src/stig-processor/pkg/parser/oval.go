@@ -0,0 +1,259 @@
+package parser
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// ovalDocument mirrors the subset of an OVAL 5.x definitions document
+// (DISA's companion "*-oval.xml" to an XCCDF benchmark) this tool needs
+// to resolve a <check-content-ref name="oval:...:def:N"> into concrete
+// registry/service/file criteria, rather than leaving an XCCDF rule's
+// OVAL-backed check unresolved. Only the registry_test/file_test/
+// servicename_test families are handled - the ones DISA's Windows
+// benchmarks actually use for registry/service/file rules - everything
+// else in tests/objects/states is ignored.
+type ovalDocument struct {
+	Definitions []ovalDefinition `xml:"definitions>definition"`
+	Tests       []ovalTest       `xml:"tests>registry_test"`
+	FileTests   []ovalTest       `xml:"tests>file_test"`
+	SvcTests    []ovalTest       `xml:"tests>servicename_test"`
+	Objects     []ovalObject     `xml:"objects>registry_object"`
+	FileObjects []ovalObject     `xml:"objects>file_object"`
+	SvcObjects  []ovalObject     `xml:"objects>servicename_object"`
+	States      []ovalState      `xml:"states>registry_state"`
+	FileStates  []ovalState      `xml:"states>file_state"`
+	SvcStates   []ovalState      `xml:"states>servicename_state"`
+}
+
+type ovalDefinition struct {
+	ID       string         `xml:"id,attr"`
+	Criteria []ovalCriteria `xml:"criteria"`
+}
+
+// ovalCriteria is read recursively (a <criteria> can nest further
+// <criteria>/<criterion> children); every <criterion test_ref="..."> found
+// anywhere under a definition is collected, regardless of the AND/OR
+// operator joining them - good enough to resolve what a rule checks for,
+// though not to evaluate whether it's satisfied.
+type ovalCriteria struct {
+	Criteria  []ovalCriteria  `xml:"criteria"`
+	Criterion []ovalCriterion `xml:"criterion"`
+}
+
+type ovalCriterion struct {
+	TestRef string `xml:"test_ref,attr"`
+}
+
+type ovalTest struct {
+	ID        string `xml:"id,attr"`
+	ObjectRef string `xml:"-"`
+	StateRef  string `xml:"-"`
+}
+
+// UnmarshalXML reads ObjectRef and StateRef from the test's <object
+// object_ref="..."/> and <state state_ref="..."/> children.
+func (t *ovalTest) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw struct {
+		ID     string `xml:"id,attr"`
+		Object struct {
+			Ref string `xml:"object_ref,attr"`
+		} `xml:"object"`
+		State struct {
+			Ref string `xml:"state_ref,attr"`
+		} `xml:"state"`
+	}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	t.ID, t.ObjectRef, t.StateRef = raw.ID, raw.Object.Ref, raw.State.Ref
+	return nil
+}
+
+type ovalObject struct {
+	ID   string `xml:"id,attr"`
+	Hive string `xml:"hive"`
+	Key  string `xml:"key"`
+	Name string `xml:"name"`
+	Path string `xml:"path"`
+}
+
+type ovalState struct {
+	ID    string    `xml:"id,attr"`
+	Value ovalValue `xml:"value"`
+	Type  string    `xml:"type"`
+}
+
+type ovalValue struct {
+	Operation string `xml:"operation,attr"`
+	Text      string `xml:",chardata"`
+}
+
+// parseOVALDocument unmarshals an OVAL definitions document's raw bytes.
+func parseOVALDocument(data []byte) (*ovalDocument, error) {
+	var doc ovalDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OVAL XML: %w", err)
+	}
+	return &doc, nil
+}
+
+func (doc *ovalDocument) criterionTestRefs(defID string) []string {
+	for _, def := range doc.Definitions {
+		if def.ID != defID {
+			continue
+		}
+		var refs []string
+		var walk func([]ovalCriteria)
+		walk = func(cs []ovalCriteria) {
+			for _, c := range cs {
+				for _, crit := range c.Criterion {
+					refs = append(refs, crit.TestRef)
+				}
+				walk(c.Criteria)
+			}
+		}
+		walk(def.Criteria)
+		return refs
+	}
+	return nil
+}
+
+// resolveCheckContent renders the OVAL definition identified by defID as
+// free-text check content in the same "Registry Hive: ...\nRegistry
+// Path: ...\n..." / "the <name> service must be <state>" / "file <path>
+// must (not) exist" phrasings ParseRegistryCheck and pkg/extractor's
+// built-in extractors already recognize, instead of introducing a
+// parallel OVAL-specific representation. ok is false if defID isn't
+// found or none of its tests resolve to a recognized family.
+func (doc *ovalDocument) resolveCheckContent(defID string) (content string, ok bool) {
+	var lines []string
+	for _, ref := range doc.criterionTestRefs(defID) {
+		if line, resolved := doc.resolveTest(ref); resolved {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return "", false
+	}
+	return strings.Join(lines, "\n"), true
+}
+
+func (doc *ovalDocument) resolveTest(testRef string) (string, bool) {
+	for _, t := range doc.Tests {
+		if t.ID == testRef {
+			return doc.resolveRegistryTest(t)
+		}
+	}
+	for _, t := range doc.FileTests {
+		if t.ID == testRef {
+			return doc.resolveFileTest(t)
+		}
+	}
+	for _, t := range doc.SvcTests {
+		if t.ID == testRef {
+			return doc.resolveServiceTest(t)
+		}
+	}
+	return "", false
+}
+
+func (doc *ovalDocument) resolveRegistryTest(t ovalTest) (string, bool) {
+	var obj *ovalObject
+	for i := range doc.Objects {
+		if doc.Objects[i].ID == t.ObjectRef {
+			obj = &doc.Objects[i]
+			break
+		}
+	}
+	if obj == nil {
+		return "", false
+	}
+	var st *ovalState
+	for i := range doc.States {
+		if doc.States[i].ID == t.StateRef {
+			st = &doc.States[i]
+			break
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Registry Hive: %s\n", obj.Hive)
+	fmt.Fprintf(&b, "Registry Path: \\%s\\\n", strings.Trim(obj.Key, `\`))
+	fmt.Fprintf(&b, "Value Name: %s\n", obj.Name)
+	if st != nil {
+		if st.Type != "" {
+			fmt.Fprintf(&b, "Value Type: %s\n", st.Type)
+		}
+		fmt.Fprintf(&b, "Value: %s\n", ovalOperationPhrase(st.Value.Operation, strings.TrimSpace(st.Value.Text)))
+	}
+	return b.String(), true
+}
+
+func (doc *ovalDocument) resolveFileTest(t ovalTest) (string, bool) {
+	var obj *ovalObject
+	for i := range doc.FileObjects {
+		if doc.FileObjects[i].ID == t.ObjectRef {
+			obj = &doc.FileObjects[i]
+			break
+		}
+	}
+	if obj == nil {
+		return "", false
+	}
+	path := strings.TrimRight(obj.Path, `\/`) + "/" + obj.Name
+	if obj.Name == "" {
+		path = obj.Path
+	}
+
+	exists := true
+	for i := range doc.FileStates {
+		if doc.FileStates[i].ID == t.StateRef {
+			exists = !strings.EqualFold(doc.FileStates[i].Value.Operation, "not equal")
+			break
+		}
+	}
+	if exists {
+		return fmt.Sprintf("file %s must exist", path), true
+	}
+	return fmt.Sprintf("file %s must not exist", path), true
+}
+
+func (doc *ovalDocument) resolveServiceTest(t ovalTest) (string, bool) {
+	var obj *ovalObject
+	for i := range doc.SvcObjects {
+		if doc.SvcObjects[i].ID == t.ObjectRef {
+			obj = &doc.SvcObjects[i]
+			break
+		}
+	}
+	if obj == nil {
+		return "", false
+	}
+	state := "running"
+	for i := range doc.SvcStates {
+		if doc.SvcStates[i].ID == t.StateRef {
+			if strings.Contains(strings.ToLower(doc.SvcStates[i].Value.Text), "stop") {
+				state = "stopped"
+			}
+			break
+		}
+	}
+	return fmt.Sprintf("the %s service must be %s", obj.Name, state), true
+}
+
+// ovalOperationPhrase renders an OVAL <value operation="..."> comparison
+// against value in the "1 or greater" / "1 or less" phrasing
+// determineComparison recognizes, so a resolved OVAL registry check gets
+// the same Comparison a hand-written STIG check-content string would.
+func ovalOperationPhrase(operation, value string) string {
+	switch strings.ToLower(operation) {
+	case "greater than or equal":
+		return value + " or greater"
+	case "less than or equal":
+		return value + " or less"
+	default:
+		return value
+	}
+}
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,6 +11,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/stig-processor/pkg/extractor"
+	"github.com/stig-processor/pkg/parser/grammar"
 	"github.com/stig-processor/pkg/types"
 )
 
@@ -61,9 +65,126 @@ func (p *STIGParser) ParseSTIGFile(filePath string) (*types.STIGBenchmark, error
 	return &stig, nil
 }
 
+// ParseSTIGFileStreaming parses filePath the same as ParseSTIGFile, but
+// decodes the top-level "groups" array incrementally instead of into an
+// in-memory slice: each types.STIGGroup is sent to groups as soon as
+// it's decoded. The returned STIGBenchmark's Groups field is left nil;
+// its other fields (Title, Version, Description, ...) are populated as
+// usual. groups is never closed by this method - the caller owns it.
+// This is what backs ProcessingOptions.StreamOutput, so the whole
+// quarterly DISA release's rules don't need to fit in memory at once.
+func (p *STIGParser) ParseSTIGFileStreaming(ctx context.Context, filePath string, groups chan<- types.STIGGroup) (*types.STIGBenchmark, error) {
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access file %s: %w", filePath, err)
+	}
+
+	if fileInfo.Size() > types.DefaultMaxFileSize {
+		return nil, fmt.Errorf("file %s is too large (%d bytes), maximum allowed is %d bytes",
+			filePath, fileInfo.Size(), types.DefaultMaxFileSize)
+	}
+
+	if p.verbose {
+		fmt.Printf("Streaming STIG file: %s (%d bytes)\n", filePath, fileInfo.Size())
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(f))
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("failed to parse STIG JSON: expected a top-level object")
+	}
+
+	// Every top-level field except "groups" is a small scalar; those are
+	// collected here and folded into the returned STIGBenchmark at the
+	// end via one more json round-trip, rather than hand-decoding each
+	// field. "groups" is the only field big enough to matter, and it's
+	// streamed straight to the caller instead.
+	meta := make(map[string]json.RawMessage)
+	count := 0
+
+	for dec.More() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		keyTok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse STIG JSON: %w", err)
+		}
+		key, _ := keyTok.(string)
+
+		if key != "groups" {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, fmt.Errorf("failed to parse STIG JSON field %q: %w", key, err)
+			}
+			meta[key] = raw
+			continue
+		}
+
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+			return nil, fmt.Errorf(`failed to parse STIG JSON: "groups" must be an array`)
+		}
+		for dec.More() {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+
+			var group types.STIGGroup
+			if err := dec.Decode(&group); err != nil {
+				return nil, fmt.Errorf("failed to parse STIG group %d: %w", count, err)
+			}
+
+			select {
+			case groups <- group:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			count++
+		}
+		if _, err := dec.Token(); err != nil { // consume the closing ']'
+			return nil, fmt.Errorf("failed to parse STIG JSON: %w", err)
+		}
+	}
+
+	if _, err := dec.Token(); err != nil { // consume the closing '}'
+		return nil, fmt.Errorf("failed to parse STIG JSON: %w", err)
+	}
+
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse STIG JSON metadata: %w", err)
+	}
+
+	var stig types.STIGBenchmark
+	if err := json.Unmarshal(metaBytes, &stig); err != nil {
+		return nil, fmt.Errorf("failed to parse STIG JSON metadata: %w", err)
+	}
+
+	if p.verbose {
+		fmt.Printf("Streamed STIG: %s v%s with %d rules\n", stig.Title, stig.Version, count)
+	}
+
+	return &stig, nil
+}
+
 // RegistryParser handles parsing Windows registry check patterns
 type RegistryParser struct {
 	verbose bool
+	// legacy forces the original regex-based parser instead of the
+	// grammar-driven one in pkg/parser/grammar. Useful for diffing output
+	// against the new parser while migrating fixtures.
+	legacy bool
 	// Compiled regex patterns for better performance
 	hiveRegex      *regexp.Regexp
 	pathRegex      *regexp.Regexp
@@ -84,8 +205,68 @@ func NewRegistryParser(verbose bool) *RegistryParser {
 	}
 }
 
+// UseLegacyParser switches this RegistryParser back to the original
+// regex-only implementation instead of pkg/parser/grammar. It exists so
+// callers can diff the grammar parser's output against the legacy path
+// for every rule in the fixtures while migrating.
+func (rp *RegistryParser) UseLegacyParser(legacy bool) {
+	rp.legacy = legacy
+}
+
 // ParseRegistryCheck extracts registry check information from STIG rule check content
 func (rp *RegistryParser) ParseRegistryCheck(checkContent string) ([]*types.RegistryCheck, bool) {
+	if rp.legacy {
+		return rp.parseRegistryCheckLegacy(checkContent)
+	}
+
+	blocks, err := grammar.Parse(checkContent)
+	if err != nil {
+		return nil, false
+	}
+
+	var checks []*types.RegistryCheck
+	for _, block := range blocks {
+		if !rp.isValidRegistryHive(block.Hive) {
+			if rp.verbose {
+				fmt.Printf("  Invalid registry hive: %s\n", block.Hive)
+			}
+			continue
+		}
+
+		value := ""
+		var values []string
+		for _, raw := range block.Condition.Values {
+			values = append(values, rp.cleanRegistryValue(raw))
+		}
+		if len(values) > 0 {
+			value = values[0]
+		}
+
+		checks = append(checks, &types.RegistryCheck{
+			Hive:       block.Hive,
+			Path:       block.Path,
+			ValueName:  block.ValueName,
+			ValueType:  block.ValueType,
+			Value:      value,
+			Values:     values,
+			Comparison: block.Condition.Comparison,
+		})
+	}
+
+	if len(checks) == 0 {
+		if rp.verbose {
+			fmt.Printf("  grammar parser found no usable registry checks, falling back to legacy parser\n")
+		}
+		return rp.parseRegistryCheckLegacy(checkContent)
+	}
+
+	return checks, true
+}
+
+// parseRegistryCheckLegacy is the original loose-regex implementation, kept
+// as a fallback behind UseLegacyParser / the grammar parser's own fallback
+// so output can be diffed during the migration to pkg/parser/grammar.
+func (rp *RegistryParser) parseRegistryCheckLegacy(checkContent string) ([]*types.RegistryCheck, bool) {
 	// Quick check - if it doesn't mention registry, it's not a registry check
 	if !strings.Contains(checkContent, "Registry Hive:") {
 		return nil, false
@@ -265,9 +446,8 @@ func (rp *RegistryParser) GenerateOsquerySQL(regChecks []*types.RegistryCheck) s
 					// Long string - just check it exists and is not empty
 					conditions = append(conditions, fmt.Sprintf("(path = '%s' AND data != '' AND LENGTH(data) > 0)", fullPath))
 				} else if check.Value != "" {
-					// Short string - exact match, escape single quotes
-					escapedValue := strings.ReplaceAll(check.Value, "'", "''")
-					conditions = append(conditions, fmt.Sprintf("(path = '%s' AND data = '%s')", fullPath, escapedValue))
+					// Short string - exact match against any acceptable value
+					conditions = append(conditions, fmt.Sprintf("(path = '%s' AND %s)", fullPath, rp.sqlDataEqualsAny(check)))
 				} else {
 					// No expected value - just check exists
 					conditions = append(conditions, fmt.Sprintf("(path = '%s' AND data IS NOT NULL)", fullPath))
@@ -277,13 +457,7 @@ func (rp *RegistryParser) GenerateOsquerySQL(regChecks []*types.RegistryCheck) s
 				conditions = append(conditions, fmt.Sprintf("(path = '%s' AND data != '' AND LENGTH(data) > 0)", fullPath))
 			} else {
 				// Default equals comparison (REG_DWORD, REG_QWORD, etc.)
-				if rp.isNumericValue(check.Value, check.ValueType) {
-					conditions = append(conditions, fmt.Sprintf("(path = '%s' AND data = '%s')", fullPath, check.Value))
-				} else {
-					// Escape single quotes for safety
-					escapedValue := strings.ReplaceAll(check.Value, "'", "''")
-					conditions = append(conditions, fmt.Sprintf("(path = '%s' AND data = '%s')", fullPath, escapedValue))
-				}
+				conditions = append(conditions, fmt.Sprintf("(path = '%s' AND %s)", fullPath, rp.sqlDataEqualsAny(check)))
 			}
 		}
 	}
@@ -300,6 +474,132 @@ func (rp *RegistryParser) GenerateOsquerySQL(regChecks []*types.RegistryCheck) s
 	return fmt.Sprintf("SELECT 1 FROM registry WHERE %s;", strings.Join(conditions, " AND "))
 }
 
+// GenerateRegoPolicy converts registry checks into an OPA Rego module that
+// evaluates the same registry checks as GenerateOsquerySQL, but against a
+// structured host-state input document (input.registry[hive][path][valueName])
+// instead of an osquery table. This lets consumers without osquery evaluate
+// the rule through OPA/conftest. Where GenerateOsquerySQL only checks that a
+// value is present (a REG_MULTI_SZ value, a REG_SZ/REG_EXPAND_SZ expected
+// value over 50 characters, or an empty expected value), the Rego module
+// compares the value itself.
+func (rp *RegistryParser) GenerateRegoPolicy(ruleID string, regChecks []*types.RegistryCheck) string {
+	if len(regChecks) == 0 {
+		return ""
+	}
+
+	pkgName := rp.sanitizeRegoPackageName(ruleID)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package stig.%s\n\n", pkgName)
+	b.WriteString("default compliant = false\n\n")
+	b.WriteString("compliant {\n")
+
+	for i, check := range regChecks {
+		fmt.Fprintf(&b, "\t%s\n", rp.regoCondition(fmt.Sprintf("c%d", i), check))
+	}
+
+	b.WriteString("}\n\n")
+	b.WriteString("deny[msg] {\n")
+	b.WriteString("\tnot compliant\n")
+	fmt.Fprintf(&b, "\tmsg := \"%s is not compliant\"\n", ruleID)
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// regoCondition renders a single registry check as a Rego expression that
+// reads the host-state input document, using GenerateOsquerySQL's
+// comparisons except that string values are always compared (see
+// GenerateRegoPolicy). When check.Values holds more than one
+// acceptable alternative (a REG_MULTI_SZ list or an "or"-separated
+// condition), the equality is expressed against a Rego array literal with
+// the `[_]` existential index instead of a single `==` comparison, so the
+// condition is satisfied by a host value matching ANY alternative - not
+// just the first - while still combining with this rule's other
+// conditions under `compliant`'s implicit AND.
+func (rp *RegistryParser) regoCondition(binding string, check *types.RegistryCheck) string {
+	ref := fmt.Sprintf("input.registry[%q][%q][%q]", check.Hive, check.Path, check.ValueName)
+
+	switch check.Comparison {
+	case "not_exists":
+		return fmt.Sprintf("not %s", ref)
+	case "must_exist":
+		return fmt.Sprintf("%s", ref)
+	case "greater_equal":
+		return fmt.Sprintf("%s := to_number(%s); %s >= %s", binding, ref, binding, check.Value)
+	case "less_equal":
+		return fmt.Sprintf("%s := to_number(%s); %s <= %s", binding, ref, binding, check.Value)
+	default:
+		values := check.Values
+		if len(values) == 0 {
+			values = []string{check.Value}
+		}
+		if check.ValueType == types.RegDWord || check.ValueType == types.RegQWord {
+			if len(values) == 1 {
+				return fmt.Sprintf("%s := to_number(%s); %s == %s", binding, ref, binding, values[0])
+			}
+			return fmt.Sprintf("%s := to_number(%s); %s == [%s][_]", binding, ref, binding, strings.Join(values, ", "))
+		}
+		// REG_SZ / REG_EXPAND_SZ / REG_MULTI_SZ compare as strings
+		if len(values) == 1 {
+			return fmt.Sprintf("%s == %q", ref, values[0])
+		}
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = fmt.Sprintf("%q", v)
+		}
+		return fmt.Sprintf("%s == [%s][_]", ref, strings.Join(quoted, ", "))
+	}
+}
+
+// RegoPackageName returns the Rego package path segment
+// GenerateRegoPolicy uses for ruleID, so callers that need a module's
+// package name without regenerating (or re-parsing) its body - e.g. a
+// bundle's main.rego import list - don't have to duplicate the
+// sanitization rules.
+func (rp *RegistryParser) RegoPackageName(ruleID string) string {
+	return rp.sanitizeRegoPackageName(ruleID)
+}
+
+// sanitizeRegoPackageName converts a rule identifier into a valid Rego
+// package path segment (lowercase, underscore-separated).
+func (rp *RegistryParser) sanitizeRegoPackageName(ruleID string) string {
+	name := strings.ToLower(ruleID)
+	reg := regexp.MustCompile(`[^a-z0-9]+`)
+	name = reg.ReplaceAllString(name, "_")
+	name = strings.Trim(name, "_")
+	if name == "" {
+		name = "rule"
+	}
+	return name
+}
+
+// sqlDataEqualsAny renders an equality condition against every value
+// check.Values accepts (falling back to check.Value alone when there's
+// only one), joined with OR, so a REG_MULTI_SZ/"or"-alternative condition
+// matches a compliant host whose registry value is any one of the
+// acceptable alternatives rather than only the first one.
+func (rp *RegistryParser) sqlDataEqualsAny(check *types.RegistryCheck) string {
+	values := check.Values
+	if len(values) == 0 {
+		values = []string{check.Value}
+	}
+
+	clauses := make([]string, 0, len(values))
+	for _, v := range values {
+		if rp.isNumericValue(v, check.ValueType) {
+			clauses = append(clauses, fmt.Sprintf("data = '%s'", v))
+		} else {
+			clauses = append(clauses, fmt.Sprintf("data = '%s'", strings.ReplaceAll(v, "'", "''")))
+		}
+	}
+
+	if len(clauses) == 1 {
+		return clauses[0]
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")"
+}
+
 // isNumericValue determines if a registry value should be treated as numeric
 func (rp *RegistryParser) isNumericValue(value, valueType string) bool {
 	// REG_DWORD and REG_QWORD are always numeric
@@ -354,6 +654,7 @@ func (s *Statistics) AnalyzeSTIG(stig *types.STIGBenchmark) *types.ProcessingSta
 	}
 
 	regParser := NewRegistryParser(false) // Don't need verbose for stats
+	gpParser := NewGroupPolicyParser(false)
 
 	for _, group := range stig.Groups {
 		// Count by severity
@@ -363,8 +664,21 @@ func (s *Statistics) AnalyzeSTIG(stig *types.STIGBenchmark) *types.ProcessingSta
 		// Categorize rule type
 		if _, isRegistry := regParser.ParseRegistryCheck(group.RuleCheckContent); isRegistry {
 			stats.RegistryRules++
-		} else if s.isGroupPolicyRule(group.RuleCheckContent) {
+		} else if _, isGroupPolicy := gpParser.ParseGroupPolicyCheck(group.RuleCheckContent); isGroupPolicy {
 			stats.GroupPolicyRules++
+		} else if name, _, extracted := extractor.ExtractAll(group.RuleCheckContent); extracted {
+			switch name {
+			case "service":
+				stats.ServiceRules++
+			case "file":
+				stats.FileRules++
+			case "user":
+				stats.UserRules++
+			case "audit":
+				stats.AuditRules++
+			case "powershell":
+				stats.PowerShellRules++
+			}
 		} else {
 			stats.ManualRules++
 		}
@@ -374,28 +688,6 @@ func (s *Statistics) AnalyzeSTIG(stig *types.STIGBenchmark) *types.ProcessingSta
 	return stats
 }
 
-// isGroupPolicyRule determines if a rule is related to Group Policy
-func (s *Statistics) isGroupPolicyRule(checkContent string) bool {
-	groupPolicyIndicators := []string{
-		"Group Policy",
-		"gpedit.msc",
-		"Local Group Policy Editor",
-		"Computer Configuration >> Administrative Templates",
-		"User Configuration >> Administrative Templates",
-		"gpresult",
-		"Administrative Templates",
-	}
-
-	checkLower := strings.ToLower(checkContent)
-	for _, indicator := range groupPolicyIndicators {
-		if strings.Contains(checkLower, strings.ToLower(indicator)) {
-			return true
-		}
-	}
-
-	return false
-}
-
 // ValidateRegistryChecks performs additional validation on parsed registry checks
 func (rp *RegistryParser) ValidateRegistryChecks(regChecks []*types.RegistryCheck) []types.ValidationError {
 	var errors []types.ValidationError
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateOsquerySQL performs a lightweight lexical check on a generated
+// osquery statement, catching malformed string literals and structural
+// mistakes (unbalanced parens/quotes, a missing leading SELECT) at generation
+// time instead of at deploy time.
+func ValidateOsquerySQL(query string) error {
+	trimmed := strings.TrimSpace(query)
+	if trimmed == "" {
+		return fmt.Errorf("query is empty")
+	}
+
+	lower := strings.ToLower(trimmed)
+	if !strings.HasPrefix(lower, "select") {
+		return fmt.Errorf("query must start with SELECT")
+	}
+
+	if err := checkBalanced(trimmed); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkBalanced walks the query character by character tracking quote and
+// parenthesis nesting, the way a lexer's first pass would, so an unterminated
+// string literal or an unbalanced paren is caught before the query ever
+// reaches osquery.
+func checkBalanced(query string) error {
+	inString := false
+	depth := 0
+
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\'' && inString:
+			// A doubled '' inside a string is an escaped quote, not a terminator.
+			if i+1 < len(runes) && runes[i+1] == '\'' {
+				i++
+				continue
+			}
+			inString = false
+		case c == '\'':
+			inString = true
+		case c == '(' && !inString:
+			depth++
+		case c == ')' && !inString:
+			depth--
+			if depth < 0 {
+				return fmt.Errorf("unbalanced parentheses: unexpected ')'")
+			}
+		}
+	}
+
+	if inString {
+		return fmt.Errorf("unterminated string literal")
+	}
+	if depth != 0 {
+		return fmt.Errorf("unbalanced parentheses: %d unclosed '('", depth)
+	}
+
+	return nil
+}
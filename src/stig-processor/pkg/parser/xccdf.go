@@ -0,0 +1,285 @@
+package parser
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// xccdfBenchmark mirrors the subset of SCAP 1.2/1.3 XCCDF XML this tool
+// needs: benchmark metadata plus the Group/Rule/check/fix tree. Namespaces
+// are ignored via xml.Name.Local matching, since DISA benchmarks vary in
+// which prefix they bind to the XCCDF namespace.
+type xccdfBenchmark struct {
+	XMLName xml.Name     `xml:"Benchmark"`
+	ID      string       `xml:"id,attr"`
+	Title   string       `xml:"title"`
+	Version string       `xml:"version"`
+	Status  string       `xml:"status"`
+	Groups  []xccdfGroup `xml:"Group"`
+}
+
+type xccdfGroup struct {
+	ID    string    `xml:"id,attr"`
+	Title string    `xml:"title"`
+	Rule  xccdfRule `xml:"Rule"`
+}
+
+type xccdfRule struct {
+	ID          string       `xml:"id,attr"`
+	Severity    string       `xml:"severity,attr"`
+	Weight      string       `xml:"weight,attr"`
+	Version     string       `xml:"version"`
+	Title       string       `xml:"title"`
+	Description string       `xml:"description"`
+	Ident       []xccdfIdent `xml:"ident"`
+	Check       xccdfCheck   `xml:"check"`
+	Fixtext     string       `xml:"fixtext"`
+	Fix         struct {
+		ID string `xml:"id,attr"`
+	} `xml:"fix"`
+}
+
+type xccdfIdent struct {
+	System string `xml:"system,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type xccdfCheck struct {
+	System   string   `xml:"system,attr"`
+	CheckRef xccdfRef `xml:"check-content-ref"`
+	Content  string   `xml:"check-content"`
+}
+
+type xccdfRef struct {
+	Href string `xml:"href,attr"`
+	Name string `xml:"name,attr"`
+}
+
+// DetectFormat sniffs an input file's framework by extension. It does not
+// open the file, so it's safe to call before deciding which loader to use.
+func DetectFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xml":
+		return "xccdf"
+	case ".zip":
+		return "scap"
+	case ".yml", ".yaml":
+		return "cis-yaml"
+	default:
+		return "stig-json"
+	}
+}
+
+// LoadBenchmark parses filePath with whichever of this package's loaders
+// matches format - "xccdf", "scap", "cis-yaml", or "stig-json"/"" (the
+// ParseSTIGFile default, also used as the fallback for an unrecognized
+// format string). An empty format defers entirely to DetectFormat, so a
+// caller that already has an explicit --input-format flag value and one
+// that wants auto-detection share this single entry point.
+func (p *STIGParser) LoadBenchmark(filePath, format string) (*types.STIGBenchmark, error) {
+	if format == "" {
+		format = DetectFormat(filePath)
+	}
+	switch format {
+	case "xccdf":
+		return p.ParseXCCDFFile(filePath)
+	case "scap":
+		return p.ParseSCAPDataStream(filePath)
+	case "cis-yaml":
+		return p.ParseCISYAML(filePath)
+	default:
+		return p.ParseSTIGFile(filePath)
+	}
+}
+
+// ParseXCCDFFile loads a SCAP 1.2/1.3 XCCDF Benchmark XML document and
+// normalizes it into the same types.STIGBenchmark shape ParseSTIGFile
+// produces, so the rest of the pipeline (registry extraction, osquery
+// generation, statistics) works unchanged regardless of source format. If
+// a companion "*-oval.xml" file sits next to filePath (DISA ships XCCDF
+// and OVAL as siblings in its non-zipped benchmark downloads too), each
+// rule's OVAL-backed check is resolved against it - see
+// parseXCCDFDocument. A rule whose OVAL definition can't be resolved, or
+// that has no companion OVAL file at all, keeps its raw check-content
+// unchanged, same as before this resolution existed.
+func (p *STIGParser) ParseXCCDFFile(filePath string) (*types.STIGBenchmark, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	var oval *ovalDocument
+	if ovalPath := findCompanionOVALFile(filePath); ovalPath != "" {
+		ovalData, err := os.ReadFile(ovalPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read companion OVAL file %s: %w", ovalPath, err)
+		}
+		oval, err = parseOVALDocument(ovalData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stig, err := parseXCCDFDocument(data, oval)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.verbose {
+		fmt.Printf("Parsed XCCDF benchmark: %s v%s with %d rules\n", stig.Title, stig.Version, len(stig.Groups))
+	}
+
+	return stig, nil
+}
+
+// findCompanionOVALFile looks next to an XCCDF benchmark file for the
+// "*-oval.xml" DISA ships alongside it, returning "" if none is found.
+// DISA's naming varies ("U_..._V1R1_STIG-xccdf.xml" paired with
+// "U_..._V1R1_STIG-oval.xml", or an unrelated prefix entirely), so this
+// matches by directory instead of deriving the name from filePath.
+func findCompanionOVALFile(filePath string) string {
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(filePath), "*-oval.xml"))
+	if err != nil || len(matches) == 0 {
+		return ""
+	}
+	return matches[0]
+}
+
+// parseXCCDFDocument unmarshals an XCCDF benchmark document's raw bytes
+// into a types.STIGBenchmark, resolving each rule's check-content-ref
+// against oval when oval is non-nil and the rule's check system names
+// OVAL. It's shared by ParseXCCDFFile (reading from disk) and
+// ParseSCAPDataStream (reading from a zip entry).
+func parseXCCDFDocument(data []byte, oval *ovalDocument) (*types.STIGBenchmark, error) {
+	var doc xccdfBenchmark
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse XCCDF XML: %w", err)
+	}
+
+	stig := &types.STIGBenchmark{
+		BenchmarkID: doc.ID,
+		Title:       doc.Title,
+		Version:     doc.Version,
+		Status:      doc.Status,
+		Framework:   "xccdf",
+	}
+
+	for _, g := range doc.Groups {
+		rule := g.Rule
+
+		externalIDs := make(map[string]string)
+		for _, ident := range rule.Ident {
+			if ident.System != "" {
+				externalIDs[identKey(ident.System)] = strings.TrimSpace(ident.Value)
+			}
+		}
+
+		checkContent := rule.Check.Content
+		if oval != nil && strings.Contains(strings.ToLower(rule.Check.System), "oval") {
+			if resolved, ok := oval.resolveCheckContent(rule.Check.CheckRef.Name); ok {
+				checkContent = resolved
+			}
+		}
+
+		stig.Groups = append(stig.Groups, types.STIGGroup{
+			GroupID:            g.ID,
+			Title:              g.Title,
+			RuleID:             rule.ID,
+			RuleWeight:         rule.Weight,
+			RuleSeverity:       rule.Severity,
+			RuleVersion:        rule.Version,
+			RuleTitle:          rule.Title,
+			RuleVulnDiscussion: rule.Description,
+			RuleIdent:          externalIDs["cci"],
+			RuleFixText:        rule.Fixtext,
+			RuleFixID:          rule.Fix.ID,
+			RuleCheckSystem:    rule.Check.System,
+			RuleCheckContent:   checkContent,
+			ExternalIDs:        externalIDs,
+		})
+	}
+
+	return stig, nil
+}
+
+// ParseSCAPDataStream loads a DISA SCAP data-stream ZIP - the form DISA
+// actually publishes on release day, bundling the XCCDF benchmark, its
+// OVAL definitions, and CPE dictionaries together - and normalizes the
+// enclosed XCCDF benchmark into a types.STIGBenchmark the same way
+// ParseXCCDFFile does, resolving OVAL-backed checks against whichever
+// "*-oval.xml" entry the archive contains.
+func (p *STIGParser) ParseSCAPDataStream(filePath string) (*types.STIGBenchmark, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SCAP data stream %s: %w", filePath, err)
+	}
+	defer zr.Close()
+
+	var xccdfData, ovalData []byte
+	for _, f := range zr.File {
+		name := strings.ToLower(f.Name)
+		switch {
+		case strings.HasSuffix(name, "-oval.xml"):
+			if ovalData, err = readZipFile(f); err != nil {
+				return nil, err
+			}
+		case strings.HasSuffix(name, "-xccdf.xml"):
+			if xccdfData, err = readZipFile(f); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if xccdfData == nil {
+		return nil, fmt.Errorf("SCAP data stream %s has no *-xccdf.xml entry", filePath)
+	}
+
+	var oval *ovalDocument
+	if ovalData != nil {
+		oval, err = parseOVALDocument(ovalData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stig, err := parseXCCDFDocument(xccdfData, oval)
+	if err != nil {
+		return nil, err
+	}
+	stig.Framework = "scap"
+
+	if p.verbose {
+		fmt.Printf("Parsed SCAP data stream: %s v%s with %d rules\n", stig.Title, stig.Version, len(stig.Groups))
+	}
+
+	return stig, nil
+}
+
+// readZipFile reads a *zip.File entry's full, decompressed contents.
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip entry %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// identKey maps an XCCDF <ident system="..."> URI to the short key used in
+// STIGGroup.ExternalIDs (e.g. the CCI reference scheme becomes "cci").
+func identKey(system string) string {
+	switch {
+	case strings.Contains(system, "cci"):
+		return "cci"
+	case strings.Contains(system, "cce"):
+		return "cce"
+	default:
+		return system
+	}
+}
@@ -0,0 +1,52 @@
+package policyengine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Capabilities restricts which builtin predicates a compiled policy may
+// call, mirroring OPA's capabilities.json mechanism at a much smaller
+// scale: it's a denylist-by-omission over this package's fixed builtin
+// set, not a general plugin system.
+type Capabilities struct {
+	AllowedBuiltins []string `json:"allowedBuiltins"`
+}
+
+// defaultCapabilities permits every builtin this package implements, so
+// policiesDir deployments that don't ship a capabilities file keep
+// today's full functionality.
+func defaultCapabilities() *Capabilities {
+	return &Capabilities{AllowedBuiltins: []string{"contains", "matches", "equals", "startswith"}}
+}
+
+// loadCapabilities reads path, or returns defaultCapabilities() when path
+// is empty.
+func loadCapabilities(path string) (*Capabilities, error) {
+	if path == "" {
+		return defaultCapabilities(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read capabilities file %s: %w", path, err)
+	}
+
+	var caps Capabilities
+	if err := json.Unmarshal(data, &caps); err != nil {
+		return nil, fmt.Errorf("failed to parse capabilities file %s: %w", path, err)
+	}
+
+	return &caps, nil
+}
+
+// allows reports whether builtin is permitted by c.
+func (c *Capabilities) allows(builtin string) bool {
+	for _, allowed := range c.AllowedBuiltins {
+		if allowed == builtin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package policyengine
+
+import "github.com/stig-processor/pkg/types"
+
+// groupFields is the fixed set of STIGGroup fields an "import
+// input.group.<Field>" declaration may reference.
+var groupFields = map[string]func(types.STIGGroup) string{
+	"GroupID":            func(g types.STIGGroup) string { return g.GroupID },
+	"RuleID":             func(g types.STIGGroup) string { return g.RuleID },
+	"RuleVersion":        func(g types.STIGGroup) string { return g.RuleVersion },
+	"RuleTitle":          func(g types.STIGGroup) string { return g.RuleTitle },
+	"RuleSeverity":       func(g types.STIGGroup) string { return g.RuleSeverity },
+	"RuleVulnDiscussion": func(g types.STIGGroup) string { return g.RuleVulnDiscussion },
+	"RuleCheckContent":   func(g types.STIGGroup) string { return g.RuleCheckContent },
+	"RuleCheckSystem":    func(g types.STIGGroup) string { return g.RuleCheckSystem },
+	"RuleIdent":          func(g types.STIGGroup) string { return g.RuleIdent },
+	"RuleFixText":        func(g types.STIGGroup) string { return g.RuleFixText },
+	"RuleWeight":         func(g types.STIGGroup) string { return g.RuleWeight },
+}
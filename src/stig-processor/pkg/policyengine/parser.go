@@ -0,0 +1,266 @@
+package policyengine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+// condition is one "builtin(var, literal)" call inside an automatable
+// rule body, with var already resolved back to the STIGGroup field it
+// was bound from.
+type condition struct {
+	field   string
+	builtin string
+	literal string
+}
+
+// compiledPolicy is the result of compiling a single .rego source file.
+type compiledPolicy struct {
+	name               string
+	defaultAutomatable bool
+	hasRule            bool
+	conditions         []condition
+	queryTemplate      string
+	platforms          []string
+	tags               []string
+	remediationNotes   string
+	severity           string
+}
+
+// evaluationResult is one compiledPolicy's verdict for a single group,
+// before Engine merges it with every other policy's verdict.
+type evaluationResult struct {
+	automatable      bool
+	queryTemplate    string
+	platforms        []string
+	tags             []string
+	remediationNotes string
+	severity         string
+}
+
+func (cp *compiledPolicy) evaluate(group types.STIGGroup) evaluationResult {
+	automatable := cp.defaultAutomatable
+
+	if cp.hasRule {
+		matched := true
+		for _, c := range cp.conditions {
+			value := groupFields[c.field](group)
+			fn, ok := builtins[c.builtin]
+			if !ok || !fn(value, c.literal) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			automatable = true
+		}
+	}
+
+	return evaluationResult{
+		automatable:      automatable,
+		queryTemplate:    cp.queryTemplate,
+		platforms:        cp.platforms,
+		tags:             cp.tags,
+		remediationNotes: cp.remediationNotes,
+		severity:         cp.severity,
+	}
+}
+
+// compile parses and strict-mode-checks a single .rego source file.
+//
+// The grammar understood is intentionally tiny:
+//
+//	package <name>
+//	import input.group.<Field>
+//	default automatable = false
+//	automatable {
+//	    <var> := input.group.<Field>
+//	    <builtin>(<var>, "<literal>")
+//	}
+//	query_template := "..."
+//	platforms := ["...", "..."]
+//	tags := ["...", "..."]
+//	remediation_notes := "..."
+//	severity := "..."
+//
+// Strict mode rejects an import that the automatable body never binds
+// into a condition, a bound local variable the body never passes to a
+// builtin, and any builtin not granted by caps.
+func compile(name, source string, caps *Capabilities) (*compiledPolicy, error) {
+	cp := &compiledPolicy{name: name}
+
+	imports := map[string]bool{}   // field -> used
+	locals := map[string]string{}  // var -> field
+	localUsed := map[string]bool{} // var -> used in a builtin call
+	havePackage := false
+	inBody := false
+
+	lines := strings.Split(source, "\n")
+	for lineNum, raw := range lines {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, "#"); i >= 0 && !strings.Contains(line[:i], "\"") {
+			line = strings.TrimSpace(line[:i])
+		}
+
+		switch {
+		case strings.HasPrefix(line, "package "):
+			havePackage = true
+
+		case strings.HasPrefix(line, "import input.group."):
+			field := strings.TrimPrefix(line, "import input.group.")
+			field = strings.TrimSpace(field)
+			if _, ok := groupFields[field]; !ok {
+				return nil, fmt.Errorf("line %d: unknown group field %q", lineNum+1, field)
+			}
+			imports[field] = false
+
+		case strings.HasPrefix(line, "default automatable"):
+			val := strings.TrimSpace(strings.TrimPrefix(line, "default automatable = "))
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid default automatable value %q", lineNum+1, val)
+			}
+			cp.defaultAutomatable = b
+
+		case strings.HasPrefix(line, "automatable {") || line == "automatable{":
+			cp.hasRule = true
+			inBody = true
+			rest := strings.TrimPrefix(line, "automatable {")
+			rest = strings.TrimSpace(rest)
+			if rest == "}" {
+				inBody = false
+			}
+
+		case inBody && line == "}":
+			inBody = false
+
+		case inBody:
+			if err := parseBodyLine(line, lineNum, imports, locals, localUsed, caps, cp); err != nil {
+				return nil, err
+			}
+
+		case strings.HasPrefix(line, "query_template"):
+			cp.queryTemplate = parseStringAssignment(line)
+
+		case strings.HasPrefix(line, "remediation_notes"):
+			cp.remediationNotes = parseStringAssignment(line)
+
+		case strings.HasPrefix(line, "severity"):
+			cp.severity = parseStringAssignment(line)
+
+		case strings.HasPrefix(line, "platforms"):
+			cp.platforms = parseArrayAssignment(line)
+
+		case strings.HasPrefix(line, "tags"):
+			cp.tags = parseArrayAssignment(line)
+
+		default:
+			return nil, fmt.Errorf("line %d: unrecognized statement %q", lineNum+1, line)
+		}
+	}
+
+	if !havePackage {
+		return nil, fmt.Errorf("missing package declaration")
+	}
+	if inBody {
+		return nil, fmt.Errorf("automatable rule body missing closing brace")
+	}
+
+	for field, used := range imports {
+		if !used {
+			return nil, fmt.Errorf("unused import: input.group.%s", field)
+		}
+	}
+	for varName, used := range localUsed {
+		if !used {
+			return nil, fmt.Errorf("unused variable: %s", varName)
+		}
+	}
+
+	return cp, nil
+}
+
+func parseBodyLine(line string, lineNum int, imports map[string]bool, locals map[string]string, localUsed map[string]bool, caps *Capabilities, cp *compiledPolicy) error {
+	if strings.Contains(line, ":=") {
+		parts := strings.SplitN(line, ":=", 2)
+		varName := strings.TrimSpace(parts[0])
+		rhs := strings.TrimSpace(parts[1])
+		if !strings.HasPrefix(rhs, "input.group.") {
+			return fmt.Errorf("line %d: assignment must bind from input.group.<Field>", lineNum+1)
+		}
+		field := strings.TrimPrefix(rhs, "input.group.")
+		if _, ok := groupFields[field]; !ok {
+			return fmt.Errorf("line %d: unknown group field %q", lineNum+1, field)
+		}
+		if _, imported := imports[field]; !imported {
+			return fmt.Errorf("line %d: input.group.%s used without a matching import", lineNum+1, field)
+		}
+		imports[field] = true
+		locals[varName] = field
+		localUsed[varName] = false
+		return nil
+	}
+
+	open := strings.Index(line, "(")
+	close := strings.LastIndex(line, ")")
+	if open < 0 || close < open {
+		return fmt.Errorf("line %d: unrecognized rule body statement %q", lineNum+1, line)
+	}
+
+	name := strings.TrimSpace(line[:open])
+	args := strings.Split(line[open+1:close], ",")
+	if len(args) != 2 {
+		return fmt.Errorf("line %d: builtin %s expects exactly 2 arguments", lineNum+1, name)
+	}
+	varName := strings.TrimSpace(args[0])
+	literal := strings.TrimSpace(args[1])
+	literal = strings.Trim(literal, "\"")
+
+	field, ok := locals[varName]
+	if !ok {
+		return fmt.Errorf("line %d: %s references undeclared variable %q", lineNum+1, name, varName)
+	}
+	if _, ok := builtins[name]; !ok {
+		return fmt.Errorf("line %d: unknown builtin %q", lineNum+1, name)
+	}
+	if !caps.allows(name) {
+		return fmt.Errorf("line %d: builtin %q is not permitted by capabilities", lineNum+1, name)
+	}
+
+	localUsed[varName] = true
+	cp.conditions = append(cp.conditions, condition{field: field, builtin: name, literal: literal})
+	return nil
+}
+
+func parseStringAssignment(line string) string {
+	parts := strings.SplitN(line, ":=", 2)
+	if len(parts) != 2 {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(parts[1]), "\"")
+}
+
+func parseArrayAssignment(line string) []string {
+	parts := strings.SplitN(line, ":=", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+	raw := strings.TrimSpace(parts[1])
+	raw = strings.TrimPrefix(raw, "[")
+	raw = strings.TrimSuffix(raw, "]")
+	if raw == "" {
+		return nil
+	}
+
+	var out []string
+	for _, item := range strings.Split(raw, ",") {
+		out = append(out, strings.Trim(strings.TrimSpace(item), "\""))
+	}
+	return out
+}
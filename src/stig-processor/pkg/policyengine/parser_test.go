@@ -0,0 +1,133 @@
+package policyengine
+
+import (
+	"testing"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+func TestCompileAcceptsFullGrammar(t *testing.T) {
+	source := `package stig.promote
+import input.group.RuleVersion
+default automatable = false
+automatable {
+	v := input.group.RuleVersion
+	startswith(v, "WN11-CC")
+}
+query_template := "SELECT 1 FROM registry WHERE path = 'x';"
+platforms := ["windows"]
+tags := ["custom-policy"]
+remediation_notes := "apply the GPO"
+severity := "high"
+`
+
+	cp, err := compile("promote.rego", source, defaultCapabilities())
+	if err != nil {
+		t.Fatalf("compile failed: %v", err)
+	}
+
+	result := cp.evaluate(types.STIGGroup{RuleVersion: "WN11-CC-000001"})
+	if !result.automatable {
+		t.Fatal("expected matching group to be automatable")
+	}
+	if result.queryTemplate != "SELECT 1 FROM registry WHERE path = 'x';" {
+		t.Errorf("got queryTemplate %q", result.queryTemplate)
+	}
+	if result.severity != "high" {
+		t.Errorf("got severity %q, want high", result.severity)
+	}
+	if len(result.platforms) != 1 || result.platforms[0] != "windows" {
+		t.Errorf("got platforms %v, want [windows]", result.platforms)
+	}
+	if len(result.tags) != 1 || result.tags[0] != "custom-policy" {
+		t.Errorf("got tags %v, want [custom-policy]", result.tags)
+	}
+	if result.remediationNotes != "apply the GPO" {
+		t.Errorf("got remediationNotes %q, want \"apply the GPO\"", result.remediationNotes)
+	}
+
+	result = cp.evaluate(types.STIGGroup{RuleVersion: "WN11-SO-000001"})
+	if result.automatable {
+		t.Error("expected non-matching group to remain unautomated")
+	}
+}
+
+func TestCompileRejectsUnusedImport(t *testing.T) {
+	source := `package stig.bad
+import input.group.RuleVersion
+default automatable = false
+`
+	if _, err := compile("bad.rego", source, defaultCapabilities()); err == nil {
+		t.Fatal("expected compile to reject an unused import")
+	}
+}
+
+func TestCompileRejectsUnusedVariable(t *testing.T) {
+	source := `package stig.bad
+import input.group.RuleVersion
+default automatable = false
+automatable {
+	v := input.group.RuleVersion
+}
+`
+	if _, err := compile("bad.rego", source, defaultCapabilities()); err == nil {
+		t.Fatal("expected compile to reject a local variable bound but never used in a builtin call")
+	}
+}
+
+func TestCompileRejectsBuiltinNotGrantedByCapabilities(t *testing.T) {
+	source := `package stig.bad
+import input.group.RuleVersion
+default automatable = false
+automatable {
+	v := input.group.RuleVersion
+	startswith(v, "WN11-CC")
+}
+`
+	caps := &Capabilities{AllowedBuiltins: []string{"contains"}}
+	if _, err := compile("bad.rego", source, caps); err == nil {
+		t.Fatal("expected compile to reject a builtin not in capabilities, even though the package implements it")
+	}
+}
+
+func TestCompileRejectsUnknownBuiltin(t *testing.T) {
+	source := `package stig.bad
+import input.group.RuleVersion
+default automatable = false
+automatable {
+	v := input.group.RuleVersion
+	fuzzyMatch(v, "WN11-CC")
+}
+`
+	if _, err := compile("bad.rego", source, defaultCapabilities()); err == nil {
+		t.Fatal("expected compile to reject an unknown builtin")
+	}
+}
+
+func TestCompileRejectsMissingPackage(t *testing.T) {
+	source := `default automatable = false
+`
+	if _, err := compile("bad.rego", source, defaultCapabilities()); err == nil {
+		t.Fatal("expected compile to reject a file with no package declaration")
+	}
+}
+
+func TestCompileRejectsUnrecognizedStatement(t *testing.T) {
+	source := `package stig.bad
+default automatable = false
+not_a_real_statement := "x"
+`
+	if _, err := compile("bad.rego", source, defaultCapabilities()); err == nil {
+		t.Fatal("expected compile to reject a statement outside the supported grammar")
+	}
+}
+
+func TestCompileRejectsUnknownGroupField(t *testing.T) {
+	source := `package stig.bad
+import input.group.NotAField
+default automatable = false
+`
+	if _, err := compile("bad.rego", source, defaultCapabilities()); err == nil {
+		t.Fatal("expected compile to reject an import of an unknown group field")
+	}
+}
@@ -0,0 +1,198 @@
+// Package policyengine evaluates STIGGroups against externally supplied
+// decision policies instead of the hardcoded logic in pkg/generator, so a
+// security engineer can ship new automatable/severity/platform/remediation
+// decisions without recompiling stig-processor.
+//
+// Policies are ".rego" files, but this package does not vendor (or
+// reimplement) a full OPA/Rego evaluator. It understands a small,
+// deliberately restricted subset of Rego's shape — a package declaration,
+// "import input.group.<Field>" declarations, scalar/array assignments,
+// and a single "automatable { ... }" rule body built from builtin
+// predicate calls — compiled with strict mode enabled: an import that's
+// never referenced, or a local variable bound but never used in a
+// condition, fails compilation. A Capabilities file further restricts
+// which builtin predicates (contains, matches, equals, startswith) a
+// policy is allowed to call. This is the CLI's existing -policies-dir
+// entry point for custom rule-matching logic; see cmd/main.go's -policies-dir
+// flag and generator.FleetPolicyGenerator.SetDecisions for how compiled
+// decisions reach GeneratePolicy ahead of its own hardcoded heuristics.
+package policyengine
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+//go:embed embedded/*.rego
+var embeddedPolicies embed.FS
+
+// RuleDecision is the structured output of evaluating a STIGGroup against
+// the compiled policy set.
+type RuleDecision struct {
+	GroupID          string
+	Automatable      bool
+	QueryTemplate    string
+	Platforms        []string
+	Tags             []string
+	RemediationNotes string
+	// Severity overrides the group's RuleSeverity (e.g. "high") for
+	// generator.GeneratePolicy's criticality/labeling, the same way
+	// Platforms overrides resolvePlatform. Empty leaves RuleSeverity as-is.
+	Severity string
+}
+
+// Engine holds the compiled policy set used to evaluate STIGGroups.
+type Engine struct {
+	capabilities *Capabilities
+	policies     []*compiledPolicy
+}
+
+// NewEngine compiles every .rego file in policiesDir (or, when policiesDir
+// is empty, the embedded default bundle) against capabilitiesPath.
+//
+// A file that fails strict-mode compilation does not abort the whole
+// engine: it's skipped, and a types.ProcessingError of type
+// ErrorTypePolicyCompile describing the failure is returned alongside
+// whatever policies did compile. A non-nil error return means the engine
+// itself could not be constructed at all (e.g. a malformed Capabilities
+// file, or an explicitly configured policiesDir that doesn't exist).
+func NewEngine(policiesDir, capabilitiesPath string) (*Engine, []types.ProcessingError, error) {
+	caps, err := loadCapabilities(capabilitiesPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sources, err := loadSources(policiesDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	engine := &Engine{capabilities: caps}
+	var compileErrors []types.ProcessingError
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		cp, err := compile(name, sources[name], caps)
+		if err != nil {
+			compileErrors = append(compileErrors, types.ProcessingError{
+				Message:   fmt.Sprintf("%s: %v", name, err),
+				Type:      types.ErrorTypePolicyCompile,
+				Timestamp: time.Now(),
+			})
+			continue
+		}
+		engine.policies = append(engine.policies, cp)
+	}
+
+	return engine, compileErrors, nil
+}
+
+// EvaluateRules runs every compiled policy against every group, merging
+// their decisions: Automatable is true if any policy says so, Platforms
+// and Tags are the deduplicated union, and QueryTemplate/RemediationNotes
+// take the first non-empty value in policy file order.
+func (e *Engine) EvaluateRules(ctx context.Context, groups []types.STIGGroup) ([]RuleDecision, error) {
+	decisions := make([]RuleDecision, 0, len(groups))
+
+	for _, group := range groups {
+		select {
+		case <-ctx.Done():
+			return decisions, ctx.Err()
+		default:
+		}
+
+		decisions = append(decisions, e.evaluateGroup(group))
+	}
+
+	return decisions, nil
+}
+
+func (e *Engine) evaluateGroup(group types.STIGGroup) RuleDecision {
+	decision := RuleDecision{GroupID: group.GroupID}
+
+	seenPlatform := make(map[string]bool)
+	seenTag := make(map[string]bool)
+
+	for _, cp := range e.policies {
+		result := cp.evaluate(group)
+
+		if result.automatable {
+			decision.Automatable = true
+		}
+		if decision.QueryTemplate == "" {
+			decision.QueryTemplate = result.queryTemplate
+		}
+		if decision.RemediationNotes == "" {
+			decision.RemediationNotes = result.remediationNotes
+		}
+		if decision.Severity == "" {
+			decision.Severity = result.severity
+		}
+		for _, platform := range result.platforms {
+			if !seenPlatform[platform] {
+				seenPlatform[platform] = true
+				decision.Platforms = append(decision.Platforms, platform)
+			}
+		}
+		for _, tag := range result.tags {
+			if !seenTag[tag] {
+				seenTag[tag] = true
+				decision.Tags = append(decision.Tags, tag)
+			}
+		}
+	}
+
+	return decision
+}
+
+// loadSources reads every *.rego file from dir, or the embedded default
+// bundle when dir is empty.
+func loadSources(dir string) (map[string]string, error) {
+	if dir == "" {
+		entries, err := embeddedPolicies.ReadDir("embedded")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read embedded policies: %w", err)
+		}
+		sources := make(map[string]string, len(entries))
+		for _, entry := range entries {
+			data, err := embeddedPolicies.ReadFile(filepath.Join("embedded", entry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read embedded policy %s: %w", entry.Name(), err)
+			}
+			sources[entry.Name()] = string(data)
+		}
+		return sources, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policies directory %s: %w", dir, err)
+	}
+
+	sources := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".rego") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy %s: %w", entry.Name(), err)
+		}
+		sources[entry.Name()] = string(data)
+	}
+
+	return sources, nil
+}
@@ -0,0 +1,91 @@
+package policyengine
+
+import (
+	"testing"
+
+	"github.com/stig-processor/pkg/types"
+)
+
+func mustCompile(t *testing.T, name, source string) *compiledPolicy {
+	t.Helper()
+	cp, err := compile(name, source, defaultCapabilities())
+	if err != nil {
+		t.Fatalf("compile(%s) failed: %v", name, err)
+	}
+	return cp
+}
+
+func TestEvaluateGroupMergesAcrossPolicies(t *testing.T) {
+	first := mustCompile(t, "first.rego", `package stig.first
+import input.group.RuleVersion
+default automatable = false
+automatable {
+	v := input.group.RuleVersion
+	startswith(v, "WN11-CC")
+}
+platforms := ["windows"]
+tags := ["from-first"]
+`)
+	second := mustCompile(t, "second.rego", `package stig.second
+default automatable = false
+query_template := "SELECT 1;"
+platforms := ["windows", "linux"]
+tags := ["from-second"]
+severity := "high"
+`)
+
+	e := &Engine{policies: []*compiledPolicy{first, second}}
+	decision := e.evaluateGroup(types.STIGGroup{GroupID: "V-1", RuleVersion: "WN11-CC-000001"})
+
+	if decision.GroupID != "V-1" {
+		t.Errorf("got GroupID %q, want V-1", decision.GroupID)
+	}
+	if !decision.Automatable {
+		t.Error("expected Automatable true when any policy matches")
+	}
+	if decision.QueryTemplate != "SELECT 1;" {
+		t.Errorf("got QueryTemplate %q, want second policy's value (first left it empty)", decision.QueryTemplate)
+	}
+	if decision.Severity != "high" {
+		t.Errorf("got Severity %q, want high", decision.Severity)
+	}
+	if len(decision.Platforms) != 2 || decision.Platforms[0] != "windows" || decision.Platforms[1] != "linux" {
+		t.Errorf("got Platforms %v, want deduplicated union [windows linux]", decision.Platforms)
+	}
+	if len(decision.Tags) != 2 || decision.Tags[0] != "from-first" || decision.Tags[1] != "from-second" {
+		t.Errorf("got Tags %v, want union [from-first from-second]", decision.Tags)
+	}
+}
+
+func TestEvaluateGroupFirstNonEmptyWinsForScalarFields(t *testing.T) {
+	first := mustCompile(t, "first.rego", `package stig.first
+default automatable = false
+query_template := "first template"
+severity := "low"
+`)
+	second := mustCompile(t, "second.rego", `package stig.second
+default automatable = false
+query_template := "second template"
+severity := "high"
+`)
+
+	e := &Engine{policies: []*compiledPolicy{first, second}}
+	decision := e.evaluateGroup(types.STIGGroup{GroupID: "V-1"})
+
+	if decision.QueryTemplate != "first template" {
+		t.Errorf("got QueryTemplate %q, want the first policy's value", decision.QueryTemplate)
+	}
+	if decision.Severity != "low" {
+		t.Errorf("got Severity %q, want the first policy's value", decision.Severity)
+	}
+}
+
+func TestEvaluateGroupNoPoliciesLeavesZeroValueDecision(t *testing.T) {
+	e := &Engine{}
+	decision := e.evaluateGroup(types.STIGGroup{GroupID: "V-1"})
+
+	if decision.Automatable || decision.QueryTemplate != "" || decision.Severity != "" ||
+		len(decision.Platforms) != 0 || len(decision.Tags) != 0 || decision.RemediationNotes != "" {
+		t.Errorf("expected a zero-value decision with no compiled policies, got %+v", decision)
+	}
+}
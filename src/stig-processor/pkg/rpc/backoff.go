@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DialFunc opens a transport connection, e.g. net.Dial("tcp", addr).
+type DialFunc func() (*Conn, error)
+
+// DialWithBackoff calls dial until it succeeds or retryLimit attempts
+// have failed (retryLimit <= 0 means retry forever), doubling the delay
+// between attempts starting at 500ms and capping at 30s.
+func DialWithBackoff(ctx context.Context, retryLimit int, dial DialFunc) (*Conn, error) {
+	const (
+		initialDelay = 500 * time.Millisecond
+		maxDelay     = 30 * time.Second
+	)
+
+	delay := initialDelay
+	var lastErr error
+
+	for attempt := 1; retryLimit <= 0 || attempt <= retryLimit; attempt++ {
+		conn, err := dial()
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+
+	return nil, fmt.Errorf("failed to connect after %d attempts: %w", retryLimit, lastErr)
+}
@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// frame is the union of everything a Request or Response can carry,
+// decoded once per incoming line so Client.Listen can tell which one it
+// received without a second round-trip through json.Unmarshal.
+type frame struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Client issues Requests over a Conn and matches Responses back to their
+// caller by ID. Any incoming frame that carries a Method but no
+// matching-response shape (i.e. a notification such as a progress event)
+// is handed to OnNotification instead of a Call's waiter.
+//
+// This is the piece that lets stig-processor's server treat an agent
+// connection like a remote STIGProcessor: Call("Process", ...) blocks
+// until the agent's final Response arrives, while OnNotification streams
+// the per-rule ProcessingEvents the agent sends in between.
+type Client struct {
+	conn   *Conn
+	nextID int64
+
+	mu      sync.Mutex
+	pending map[string]chan *Response
+
+	// OnNotification, if set, is invoked for every incoming Request that
+	// has no matching pending Call.
+	OnNotification func(*Request)
+}
+
+// NewClient wraps conn as a Client. Call Listen in its own goroutine to
+// start receiving frames.
+func NewClient(conn *Conn) *Client {
+	return &Client{conn: conn, pending: make(map[string]chan *Response)}
+}
+
+// Call sends a Request for method and blocks until the matching Response
+// arrives or Listen returns (e.g. because the connection closed).
+func (c *Client) Call(method string, params any) (*Response, error) {
+	id := fmt.Sprintf("%d", atomic.AddInt64(&c.nextID, 1))
+
+	req, err := NewRequest(id, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Responses are matched by the raw JSON bytes of the id field (see
+	// Listen), so pending must be keyed the same way rather than by the
+	// plain id string.
+	key := string(req.ID)
+
+	ch := make(chan *Response, 1)
+	c.mu.Lock()
+	c.pending[key] = ch
+	c.mu.Unlock()
+
+	if err := c.conn.Send(req); err != nil {
+		c.mu.Lock()
+		delete(c.pending, key)
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	resp, ok := <-ch
+	if !ok {
+		return nil, fmt.Errorf("connection closed while waiting for %s response", method)
+	}
+	return resp, nil
+}
+
+// Notify sends a one-way Request (no ID) for method, e.g. a progress
+// event pushed from agent to server mid-job.
+func (c *Client) Notify(method string, params any) error {
+	req, err := NewRequest("", method, params)
+	if err != nil {
+		return err
+	}
+	return c.conn.Send(req)
+}
+
+// Listen reads frames from the underlying Conn until it errs (typically
+// because the peer closed the connection), routing each one to either a
+// pending Call or OnNotification. It closes every still-pending Call's
+// channel before returning, so callers blocked in Call get unblocked.
+func (c *Client) Listen() error {
+	defer c.closePending()
+
+	for {
+		var f frame
+		if err := c.conn.Receive(&f); err != nil {
+			return err
+		}
+
+		if f.Method != "" {
+			if c.OnNotification != nil {
+				c.OnNotification(&Request{JSONRPC: f.JSONRPC, ID: f.ID, Method: f.Method, Params: f.Params})
+			}
+			continue
+		}
+
+		key := string(f.ID)
+		c.mu.Lock()
+		ch, ok := c.pending[key]
+		if ok {
+			delete(c.pending, key)
+		}
+		c.mu.Unlock()
+
+		if ok {
+			ch <- &Response{JSONRPC: f.JSONRPC, ID: f.ID, Result: f.Result, Error: f.Error}
+		}
+	}
+}
+
+func (c *Client) closePending() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+}
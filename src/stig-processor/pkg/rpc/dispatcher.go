@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// HandlerFunc handles one JSON-RPC method call, given its raw params.
+type HandlerFunc func(params json.RawMessage) (any, error)
+
+// Dispatcher routes incoming Requests to registered HandlerFuncs by
+// method name.
+type Dispatcher struct {
+	handlers map[string]HandlerFunc
+}
+
+// NewDispatcher returns an empty Dispatcher.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{handlers: make(map[string]HandlerFunc)}
+}
+
+// Handle registers fn as the handler for method, overwriting any
+// previous registration.
+func (d *Dispatcher) Handle(method string, fn HandlerFunc) {
+	d.handlers[method] = fn
+}
+
+// Dispatch invokes the handler registered for req.Method and builds the
+// corresponding Response. It never panics on an unknown method or a
+// handler error; both become a JSON-RPC error response instead.
+func (d *Dispatcher) Dispatch(req *Request) *Response {
+	resp := &Response{JSONRPC: Version, ID: req.ID}
+
+	fn, ok := d.handlers[req.Method]
+	if !ok {
+		resp.Error = &Error{Code: ErrCodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+		return resp
+	}
+
+	result, err := fn(req.Params)
+	if err != nil {
+		resp.Error = &Error{Code: ErrCodeInternalError, Message: err.Error()}
+		return resp
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		resp.Error = &Error{Code: ErrCodeInternalError, Message: fmt.Sprintf("failed to marshal result: %v", err)}
+		return resp
+	}
+	resp.Result = raw
+
+	return resp
+}
+
+// Serve reads Requests from conn until conn is closed or ctx is
+// cancelled, dispatching each one. A notification (no ID) is dispatched
+// but never gets a Response written back, per the JSON-RPC 2.0 spec.
+func (d *Dispatcher) Serve(ctx context.Context, conn *Conn) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var req Request
+		if err := conn.Receive(&req); err != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to receive request: %w", err)
+		}
+
+		resp := d.Dispatch(&req)
+		if req.IsNotification() {
+			continue
+		}
+
+		if err := conn.Send(resp); err != nil {
+			return err
+		}
+	}
+}
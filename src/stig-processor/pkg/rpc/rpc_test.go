@@ -0,0 +1,202 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestConnSendReceiveRoundTrips(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	serverConn := NewConn(server)
+	clientConn := NewConn(client)
+
+	go func() {
+		req, _ := NewRequest("1", "Echo", map[string]string{"msg": "hello"})
+		_ = serverConn.Send(req)
+	}()
+
+	var got Request
+	if err := clientConn.Receive(&got); err != nil {
+		t.Fatalf("Receive failed: %v", err)
+	}
+	if got.Method != "Echo" {
+		t.Errorf("got Method %q, want Echo", got.Method)
+	}
+	if got.IsNotification() {
+		t.Error("expected a request with an ID to not be a notification")
+	}
+}
+
+func TestClientDispatcherRoundTripOverNetPipe(t *testing.T) {
+	serverSide, clientSide := net.Pipe()
+	defer serverSide.Close()
+	defer clientSide.Close()
+
+	dispatcher := NewDispatcher()
+	dispatcher.Handle("Add", func(params json.RawMessage) (any, error) {
+		var p struct{ A, B int }
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return map[string]int{"sum": p.A + p.B}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = dispatcher.Serve(ctx, NewConn(serverSide))
+	}()
+
+	client := NewClient(NewConn(clientSide))
+	go client.Listen()
+
+	resp, err := client.Call("Add", struct{ A, B int }{A: 2, B: 3})
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if resp.Error != nil {
+		t.Fatalf("unexpected error response: %v", resp.Error)
+	}
+
+	var result struct{ Sum int }
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	if result.Sum != 5 {
+		t.Errorf("got sum %d, want 5", result.Sum)
+	}
+}
+
+func TestClientDispatcherRoundTripMethodNotFound(t *testing.T) {
+	serverSide, clientSide := net.Pipe()
+	defer serverSide.Close()
+	defer clientSide.Close()
+
+	dispatcher := NewDispatcher()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = dispatcher.Serve(ctx, NewConn(serverSide))
+	}()
+
+	client := NewClient(NewConn(clientSide))
+	go client.Listen()
+
+	resp, err := client.Call("DoesNotExist", nil)
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != ErrCodeMethodNotFound {
+		t.Fatalf("got %+v, want a method-not-found error", resp.Error)
+	}
+}
+
+func TestClientNotifyDeliversToOnNotification(t *testing.T) {
+	serverSide, clientSide := net.Pipe()
+	defer serverSide.Close()
+	defer clientSide.Close()
+
+	received := make(chan string, 1)
+	server := NewClient(NewConn(serverSide))
+	server.OnNotification = func(req *Request) {
+		var p struct{ Message string }
+		_ = json.Unmarshal(req.Params, &p)
+		received <- p.Message
+	}
+	go server.Listen()
+
+	client := NewClient(NewConn(clientSide))
+	if err := client.Notify("progress", struct{ Message string }{Message: "halfway"}); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	select {
+	case msg := <-received:
+		if msg != "halfway" {
+			t.Errorf("got message %q, want halfway", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for notification")
+	}
+}
+
+func TestClientCallUnblocksWhenConnectionCloses(t *testing.T) {
+	serverSide, clientSide := net.Pipe()
+	defer clientSide.Close()
+
+	client := NewClient(NewConn(clientSide))
+	go client.Listen()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Call("Never", nil)
+		done <- err
+	}()
+
+	serverSide.Close()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("expected Call to return an error once the connection closes")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for Call to unblock")
+	}
+}
+
+func TestDialWithBackoffRetriesThenSucceeds(t *testing.T) {
+	attempts := 0
+	conn, err := DialWithBackoff(context.Background(), 5, func() (*Conn, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("connection refused")
+		}
+		server, _ := net.Pipe()
+		return NewConn(server), nil
+	})
+	if err != nil {
+		t.Fatalf("DialWithBackoff failed: %v", err)
+	}
+	if conn == nil {
+		t.Fatal("expected a non-nil Conn")
+	}
+	if attempts != 3 {
+		t.Errorf("got %d attempts, want 3", attempts)
+	}
+}
+
+func TestDialWithBackoffRespectsRetryLimit(t *testing.T) {
+	attempts := 0
+	_, err := DialWithBackoff(context.Background(), 2, func() (*Conn, error) {
+		attempts++
+		return nil, errors.New("connection refused")
+	})
+	if err == nil {
+		t.Fatal("expected DialWithBackoff to fail after exhausting retryLimit")
+	}
+	if attempts != 2 {
+		t.Errorf("got %d attempts, want 2 (retryLimit)", attempts)
+	}
+}
+
+func TestDialWithBackoffRespectsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := DialWithBackoff(ctx, 0, func() (*Conn, error) {
+		return nil, errors.New("connection refused")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+}
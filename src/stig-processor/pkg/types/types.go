@@ -12,6 +12,10 @@ type STIGBenchmark struct {
 	Title       string      `json:"title"`
 	Description string      `json:"description"`
 	Version     string      `json:"version"`
+	// Framework identifies the source format this benchmark was ingested
+	// from, e.g. "stig-json", "xccdf", "scap", "cis-yaml". Populated by
+	// whichever loader in pkg/parser produced this STIGBenchmark.
+	Framework   string      `json:"framework,omitempty"`
 	CreatedAt   string      `json:"createdAt"`
 	UpdatedAt   string      `json:"updatedAt"`
 	Groups      []STIGGroup `json:"groups"`
@@ -40,6 +44,11 @@ type STIGGroup struct {
 	RuleCheckContent   string `json:"ruleCheckContent"`
 	CreatedAt          string `json:"createdAt"`
 	UpdatedAt          string `json:"updatedAt"`
+
+	// ExternalIDs preserves identifiers from the source framework (e.g.
+	// "cis": "1.1.1", "cci": "CCI-000366") so cross-framework mappings can
+	// be resolved later without losing the original rule's identity.
+	ExternalIDs map[string]string `json:"externalIds,omitempty"`
 }
 
 // Fleet policy structures represent the output YAML format for Fleet
@@ -57,12 +66,88 @@ type PolicyMeta struct {
 }
 
 type PolicySpec struct {
-	Name        string `yaml:"name" json:"name"`
-	Query       string `yaml:"query" json:"query"`
-	Description string `yaml:"description" json:"description"`
-	Resolution  string `yaml:"resolution" json:"resolution"`
-	Platform    string `yaml:"platform" json:"platform"`
-	Critical    bool   `yaml:"critical" json:"critical"`
+	Name        string          `yaml:"name" json:"name"`
+	Query       string          `yaml:"query" json:"query"`
+	Rego        string          `yaml:"rego,omitempty" json:"rego,omitempty"`
+	Description string          `yaml:"description" json:"description"`
+	Resolution  string          `yaml:"resolution" json:"resolution"`
+	Platform    string          `yaml:"platform" json:"platform"`
+	Critical    bool            `yaml:"critical" json:"critical"`
+	Enforcement EnforcementSpec `yaml:"enforcement" json:"enforcement"`
+	// ScopedEnforcementActions optionally refines Enforcement/Critical
+	// with a distinct action per enforcement point (e.g. deny at
+	// webhook, warn at audit, for the same high-severity rule), set only
+	// when ProcessingOptions.EnforcementProfile or
+	// EnforcementProfileFile is configured - see
+	// FleetPolicyGenerator.resolveScopedEnforcement. Critical and
+	// Enforcement are always populated regardless, so a consumer that
+	// doesn't understand ScopedEnforcementActions (an older Fleet
+	// version, say) still gets the same behavior as before this field
+	// existed.
+	ScopedEnforcementActions []EnforcementAction `yaml:"scoped_enforcement_actions,omitempty" json:"scoped_enforcement_actions,omitempty"`
+	// Language names the check's evaluation language - empty (the
+	// default) means Query is plain osquery SQL. "cel" means this policy
+	// has no Query at all; Expression holds a CEL predicate instead, for
+	// rules pkg/generator.CELCheckGenerator synthesized over host state
+	// osquery can't express directly (services, users, files).
+	Language string `yaml:"language,omitempty" json:"language,omitempty"`
+	// Expression holds a CEL predicate when Language is "cel"; empty
+	// otherwise.
+	Expression string `yaml:"expression,omitempty" json:"expression,omitempty"`
+}
+
+// EnforcementSpec records how a failing check should be treated: Action
+// is one of the Enforcement* constants, and Points lists which
+// enforcement points (the Enforcement* point constants) actually apply
+// it - a dryrun Action always has an empty Points.
+type EnforcementSpec struct {
+	Action string   `yaml:"action" json:"action"`
+	Points []string `yaml:"points,omitempty" json:"points,omitempty"`
+}
+
+// Enforcement actions a generated policy's EnforcementSpec.Action (or an
+// EnforcementAction.Action below) may hold.
+const (
+	EnforcementAudit  = "audit"
+	EnforcementWarn   = "warn"
+	EnforcementDeny   = "deny"
+	EnforcementDryRun = "dryrun"
+	// EnforcementNotify is a softer signal than EnforcementWarn - surface
+	// the result somewhere a human might see it, without flagging the
+	// host as failing. Only meaningful as a per-point
+	// EnforcementAction.Action; EnforcementSpec.Action (the single,
+	// broadcast-to-every-point legacy field) predates it and sticks to
+	// the original four.
+	EnforcementNotify = "notify"
+)
+
+// Enforcement points an EnforcementSpec.Points entry, or an
+// EnforcementAction.Scope, may name - where the action is actually
+// applied.
+const (
+	EnforcementPointAudit   = "audit"
+	EnforcementPointWebhook = "webhook"
+	EnforcementPointRuntime = "runtime"
+)
+
+// ValidEnforcementActions lists every action validatePolicy accepts for
+// EnforcementSpec.Action.
+var ValidEnforcementActions = []string{EnforcementAudit, EnforcementWarn, EnforcementDeny, EnforcementDryRun}
+
+// ValidScopedEnforcementActions lists every action validatePolicy accepts
+// for an EnforcementAction.Action - EnforcementNotify in addition to
+// everything ValidEnforcementActions allows.
+var ValidScopedEnforcementActions = append(append([]string{}, ValidEnforcementActions...), EnforcementNotify)
+
+// EnforcementAction pairs one enforcement action with the single point
+// (an Enforcement point constant) it applies at, letting
+// PolicySpec.ScopedEnforcementActions express different behavior per
+// point for the same rule - e.g. deny at EnforcementPointWebhook but only
+// warn at EnforcementPointAudit - which EnforcementSpec's single
+// broadcast-to-every-point Action can't.
+type EnforcementAction struct {
+	Action string `yaml:"action" json:"action"`
+	Scope  string `yaml:"scope" json:"scope"`
 }
 
 // Registry check structure represents parsed Windows registry information
@@ -73,18 +158,204 @@ type RegistryCheck struct {
 	ValueType  string
 	Value      string
 	Comparison string // "equals", "greater_equal", "less_equal", "not_exists", "must_exist"
+
+	// Values holds every acceptable value for a "equals" comparison over a
+	// REG_MULTI_SZ value or an "or"-separated list of alternatives (e.g.
+	// "A or B or C"). Value is always Values[0] for callers that only
+	// handle a single expected value; Values is nil/len-1 for an ordinary
+	// single-value check.
+	Values []string
+}
+
+// GroupPolicyCheck represents a parsed "Computer Configuration >> ... >>
+// Setting Name: <value>" stanza from STIG check content. Path holds the
+// GPO console breadcrumb (e.g. ["Administrative Templates", "Windows
+// Components", "Windows Update"]) without the leading Scope segment.
+type GroupPolicyCheck struct {
+	Scope         string // "Computer Configuration" or "User Configuration"
+	Path          []string
+	SettingName   string
+	ExpectedValue string
+	Comparison    string // "equals", "greater_equal", "less_equal", "not_exists", "must_exist"
+}
+
+// ServiceCheck represents a parsed "the <name> service must be
+// running/stopped/disabled" stanza from STIG check content, extracted by
+// pkg/extractor's service extractor rather than RegistryParser.
+type ServiceCheck struct {
+	Name  string
+	State string // "running", "stopped", "disabled"
+}
+
+// FileCheck represents a parsed "file/directory <path> must/must not
+// exist" stanza from STIG check content, extracted by pkg/extractor's
+// file extractor.
+type FileCheck struct {
+	Path   string
+	Exists bool
+}
+
+// UserGroupCheck represents a parsed "members of the <group> group"
+// stanza from STIG check content, extracted by pkg/extractor's user
+// extractor. Allowed lists the usernames permitted in Group - any other
+// member fails the check.
+type UserGroupCheck struct {
+	Group   string
+	Allowed []string
+}
+
+// AuditPolicyCheck represents a parsed "<category>/<subcategory> audit
+// policy must be set to Success and Failure" stanza from STIG check
+// content, extracted by pkg/extractor's audit extractor.
+type AuditPolicyCheck struct {
+	Category    string
+	Subcategory string
+}
+
+// PowerShellCheck represents a parsed PowerShell logging-setting stanza
+// (script block logging, module logging, transcription) from STIG check
+// content, extracted by
+// pkg/extractor's powershell extractor. Setting is the registry-style
+// GPO setting name the check content names (e.g. "Turn on Script Block
+// Logging"); ExpectedState is "enabled" or "disabled".
+type PowerShellCheck struct {
+	Setting       string
+	ExpectedState string
 }
 
 // Processing configuration and results
 type ProcessingOptions struct {
 	InputFile string
-	OutputDir string
-	Format    string
-	Severity  string
-	Verbose   bool
-	DryRun    bool
-	Pretty    bool
-	Timeout   time.Duration
+	// InputFormat names InputFile's framework - "xccdf", "scap",
+	// "cis-yaml", or "stig-json" - for parser.STIGParser.LoadBenchmark to
+	// dispatch to the matching loader. Empty (the default) defers to
+	// parser.DetectFormat, sniffing InputFile's extension instead.
+	InputFormat string
+	OutputDir   string
+	Format      string
+	Severity    string
+	Verbose     bool
+	DryRun      bool
+	Pretty      bool
+	Timeout     time.Duration
+
+	// PoliciesDir points at a directory of .rego decision policies (see
+	// pkg/policyengine) that override the generator's hardcoded
+	// automatable/query/platform logic. Empty disables the policy engine
+	// and falls back to today's behavior.
+	PoliciesDir string
+	// CapabilitiesFile restricts which builtins PoliciesDir's policies may
+	// call. Empty uses policyengine's default (permissive) capabilities.
+	CapabilitiesFile string
+
+	// MaxProcs bounds how many STIG processing jobs the "agent" subcommand
+	// (see pkg/rpc) runs concurrently when a server dispatches more than
+	// one. 0 means unbounded. It does not affect the ordering or
+	// concurrency of rule generation within a single job.
+	MaxProcs int
+
+	// StreamOutput switches ProcessWithContext from its batch pipeline
+	// (parse the whole benchmark, generate every policy, then write
+	// everything) to a streaming one: groups are decoded, filtered,
+	// classified/generated, and written as they arrive, never all held
+	// in memory at once. The returned ProcessingResult's Policies field
+	// is left empty in this mode, since policies are written as they're
+	// produced instead of collected.
+	StreamOutput bool
+	// Workers bounds how many goroutines the streaming pipeline's
+	// generator stage runs concurrently. 0 uses DefaultStreamWorkers.
+	// Ignored unless StreamOutput is set.
+	Workers int
+
+	// EnforcementActions maps a severity level (low/medium/high, matching
+	// ValidSeverityLevels) to the EnforcementSpec.Action GeneratePolicy
+	// records for rules at that severity. A severity absent from the map
+	// defaults to EnforcementAudit.
+	EnforcementActions map[string]string
+	// DryRunSeverities forces every rule at one of these severities to
+	// EnforcementDryRun, overriding EnforcementActions - a safety valve
+	// for severities not yet trusted in active enforcement.
+	DryRunSeverities []string
+
+	// EnforcementProfile names a built-in per-severity
+	// EnforcementAction mapping (see pkg/generator's
+	// builtinEnforcementProfiles) populating
+	// PolicySpec.ScopedEnforcementActions, distinct from and additional
+	// to EnforcementActions/EnforcementSpec above. Empty leaves
+	// ScopedEnforcementActions unset entirely.
+	EnforcementProfile string
+	// EnforcementProfileFile, if set, loads a JSON file of per-severity
+	// EnforcementAction overrides layered on top of EnforcementProfile's
+	// mapping (replacing it entirely for any severity it mentions),
+	// letting an operator's own CAT-level enforcement policy override
+	// the named profile without forking it. Valid alone, without
+	// EnforcementProfile also set.
+	EnforcementProfileFile string
+
+	// CCIFilter, NISTFamilyFilter, CISBenchmarkFilter, and
+	// GroupIDGlobFilter are comma-separated pkg/filters.FilterSpec
+	// dimensions narrowing BatchGenerate beyond Severity; PlatformFilter
+	// is a single value. All are ignored when empty. FilterConfigFile,
+	// if set, loads a FilterSpec YAML file instead and takes precedence
+	// over all of the above.
+	CCIFilter           string
+	NISTFamilyFilter    string
+	CISBenchmarkFilter  string
+	PlatformFilter      string
+	GroupIDGlobFilter   string
+	FilterConfigFile    string
+	// ComplianceMappingFile, if set, replaces pkg/filters' embedded
+	// default CCI-to-NIST/CIS mapping table used to resolve
+	// NISTFamilyFilter/CISBenchmarkFilter and a policy's compliance.*
+	// annotations.
+	ComplianceMappingFile string
+
+	// Concurrency bounds how many groups BatchGenerateWithProgress
+	// classifies/generates at once in the batch (non-StreamOutput)
+	// pipeline. 0 uses runtime.NumCPU(). Unlike Workers, this applies
+	// regardless of StreamOutput.
+	Concurrency int
+	// WriteConcurrency separately bounds how many WritePolicy calls
+	// BatchGenerateWithProgress lets run at once, so a slow disk can't
+	// starve Concurrency's CPU-bound classify/generate workers. 0 uses
+	// Concurrency.
+	WriteConcurrency int
+	// FailFast stops BatchGenerateWithProgress from starting new groups
+	// once any rule in the current run has produced a ProcessingError -
+	// in-flight work still finishes, but no further group begins.
+	FailFast bool
+
+	// Strict rejects a generated policy outright (ProcessingResult.Errors,
+	// ErrorTypeCapabilityViolation) when its osquery SQL references a
+	// table/column OsqueryCapabilitiesFile doesn't allow, or trips the
+	// generator's dead-code checks (empty predicate lists, duplicate
+	// ANDs). With Strict false, the same findings are recorded as
+	// ProcessingResult.Warnings and the policy still ships.
+	Strict bool
+	// OsqueryCapabilitiesFile points at a JSON file listing the osquery
+	// tables (and each table's allowed columns) generated SQL may
+	// reference - see pkg/generator.LoadOsqueryCapabilities. Empty uses
+	// pkg/generator's default capabilities (registry only, matching what
+	// parser.RegistryParser.GenerateOsquerySQL has always emitted).
+	// Unrelated to CapabilitiesFile above, which gates PoliciesDir's
+	// decision-policy builtins rather than osquery table/column names.
+	OsqueryCapabilitiesFile string
+
+	// Bundle names a pkg/generator.BundleWriter layout to emit in place of
+	// the normal flat OutputDir - currently only BundleFleetGitOps.
+	// Empty (the default) keeps the existing one-YAML-per-rule layout.
+	Bundle string
+	// Team names the Fleet team a BundleFleetGitOps bundle's policies are
+	// scoped to (its teams/<Team>.yml and README). Defaults to "default"
+	// when Bundle is set and Team is empty.
+	Team string
+	// PreviousSTIGFile, when set, names an older STIG input file (in
+	// whatever format InputFormat/DetectFormat resolves) to diff this
+	// run's benchmark against, emitting CHANGELOG.md alongside the
+	// generated output - see pkg/generator.WriteChangelog. Unrelated to
+	// the `diff` subcommand's -previous, which compares against this
+	// tool's own manifest rather than a second STIG source file.
+	PreviousSTIGFile string
 }
 
 type ProcessingResult struct {
@@ -94,6 +365,29 @@ type ProcessingResult struct {
 	Policies     []FleetPolicy
 	Errors       []ProcessingError
 	Duration     time.Duration
+	// ComplianceRollup tallies automatable vs manual-review rule counts
+	// per NIST 800-53 control family seen in this run (see
+	// pkg/filters.ControlFamilies), sorted by family.
+	ComplianceRollup []FrameworkRollup
+	// CELAutomatable counts Policies entries generated by
+	// pkg/generator.CELCheckGenerator rather than a registry check - the
+	// subset of Automatable this backend added on top of registry
+	// parsing alone.
+	CELAutomatable int
+	// Warnings holds non-fatal ErrorTypeCapabilityViolation entries: the
+	// same osquery SQL problems Strict mode would reject a policy for,
+	// recorded here instead so the policy still ships. Can be non-empty
+	// without OsqueryCapabilitiesFile: the default (registry-only)
+	// capabilities and the dead-code checks still apply.
+	Warnings []ProcessingError
+}
+
+// FrameworkRollup tallies automatable vs manual-review rule counts for a
+// single NIST 800-53 control family (e.g. "AC", "CM").
+type FrameworkRollup struct {
+	ControlFamily string `yaml:"control_family" json:"control_family"`
+	Automatable   int    `yaml:"automatable" json:"automatable"`
+	ManualReview  int    `yaml:"manual_review" json:"manual_review"`
 }
 
 type ProcessingError struct {
@@ -111,15 +405,35 @@ const (
 	ErrorTypeValidationFailed ErrorType = "validation_failed"
 	ErrorTypeFileWriteFailed  ErrorType = "file_write_failed"
 	ErrorTypeUnknown          ErrorType = "unknown"
+	// ErrorTypePolicyCompile marks a pkg/policyengine .rego policy that
+	// failed strict-mode compilation (e.g. an unused import or unused
+	// local variable, or a builtin not granted by the Capabilities file).
+	ErrorTypePolicyCompile ErrorType = "policy_compile_failed"
+	// ErrorTypeCapabilityViolation marks generated osquery SQL that
+	// references a table/column ProcessingOptions.OsqueryCapabilitiesFile
+	// doesn't allow, or that fails the generator's dead-code checks
+	// (empty predicate lists, duplicate ANDs). ProcessingOptions.Strict
+	// decides whether it's fatal (appended to ProcessingResult.Errors) or
+	// advisory (ProcessingResult.Warnings).
+	ErrorTypeCapabilityViolation ErrorType = "capability_violation"
 )
 
 // Statistics and summary structures
 type ProcessingStatistics struct {
-	Title                string
-	Version              string
-	TotalRules           int
-	RegistryRules        int
-	GroupPolicyRules     int
+	Title            string
+	Version          string
+	TotalRules       int
+	RegistryRules    int
+	GroupPolicyRules int
+	// ServiceRules, FileRules, UserRules, AuditRules, and PowerShellRules
+	// count rules pkg/extractor's respective extractor recognized -
+	// disjoint from RegistryRules/GroupPolicyRules, and tried only after
+	// both of those decline a rule. See pkg/extractor.ExtractAll.
+	ServiceRules         int
+	FileRules            int
+	UserRules            int
+	AuditRules           int
+	PowerShellRules      int
 	ManualRules          int
 	SeverityDistribution map[string]int
 	ProcessingTime       time.Duration
@@ -134,16 +448,88 @@ type ProcessingSummary struct {
 	Timestamp         string              `yaml:"timestamp" json:"timestamp"`
 	Policies          []PolicySummaryItem `yaml:"policies" json:"policies"`
 	Errors            []ProcessingError   `yaml:"errors,omitempty" json:"errors,omitempty"`
+	// EnforcementCounts tallies generated policies per
+	// EnforcementSpec.Action, e.g. {"deny": 12, "warn": 4, "audit": 30}.
+	EnforcementCounts map[string]int `yaml:"enforcement_counts,omitempty" json:"enforcement_counts,omitempty"`
+	// ComplianceRollup mirrors ProcessingResult.ComplianceRollup.
+	ComplianceRollup []FrameworkRollup `yaml:"compliance_rollup,omitempty" json:"compliance_rollup,omitempty"`
+	// CELAutomatable mirrors ProcessingResult.CELAutomatable.
+	CELAutomatable int `yaml:"cel_automatable,omitempty" json:"cel_automatable,omitempty"`
+	// Warnings mirrors ProcessingResult.Warnings.
+	Warnings []ProcessingError `yaml:"warnings,omitempty" json:"warnings,omitempty"`
 }
 
 type PolicySummaryItem struct {
-	Name        string `yaml:"name" json:"name"`
-	Title       string `yaml:"title" json:"title"`
-	Platform    string `yaml:"platform" json:"platform"`
-	Critical    bool   `yaml:"critical" json:"critical"`
-	Severity    string `yaml:"severity" json:"severity"`
-	GroupID     string `yaml:"group_id" json:"group_id"`
-	RuleVersion string `yaml:"rule_version" json:"rule_version"`
+	Name          string   `yaml:"name" json:"name"`
+	Title         string   `yaml:"title" json:"title"`
+	Platform      string   `yaml:"platform" json:"platform"`
+	Critical      bool     `yaml:"critical" json:"critical"`
+	Severity      string   `yaml:"severity" json:"severity"`
+	GroupID       string   `yaml:"group_id" json:"group_id"`
+	RuleVersion   string   `yaml:"rule_version" json:"rule_version"`
+	Enforcement   string   `yaml:"enforcement" json:"enforcement"`
+	NISTControls  []string `yaml:"nist_controls,omitempty" json:"nist_controls,omitempty"`
+	CISBenchmarks []string `yaml:"cis_benchmarks,omitempty" json:"cis_benchmarks,omitempty"`
+	Frameworks    []string `yaml:"frameworks,omitempty" json:"frameworks,omitempty"`
+	// Language mirrors PolicySpec.Language - empty for a registry/osquery
+	// policy, "cel" for one CELCheckGenerator produced.
+	Language string `yaml:"language,omitempty" json:"language,omitempty"`
+}
+
+// Manifest records, per rule, the fingerprint STIGProcessor.ProcessDiff
+// computed for it on the run that produced OutputDir's current contents.
+// It's persisted as JSON at ManifestFilename so a later diff run can tell
+// which rules changed without re-reading every generated policy file.
+type Manifest struct {
+	Rules map[string]RuleFingerprint `json:"rules"`
+}
+
+// RuleFingerprint is the Manifest entry for a single STIG rule: the
+// policy file it produced and a fingerprint of the rule content that
+// produced it (see internal/processor.fingerprintGroup).
+type RuleFingerprint struct {
+	GroupID     string `json:"groupId"`
+	RuleID      string `json:"ruleId"`
+	PolicyFile  string `json:"policyFile"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// DiffStatus classifies a rule in a ProcessDiff run relative to the
+// Manifest left behind by the run it's being compared against.
+type DiffStatus string
+
+const (
+	// DiffStatusAdded marks a rule absent from the previous manifest.
+	DiffStatusAdded DiffStatus = "added"
+	// DiffStatusModified marks a rule present in the previous manifest
+	// whose fingerprint no longer matches.
+	DiffStatusModified DiffStatus = "modified"
+	// DiffStatusUnchanged marks a rule whose fingerprint is identical to
+	// the previous manifest's; its policy file is left untouched.
+	DiffStatusUnchanged DiffStatus = "unchanged"
+	// DiffStatusRemoved marks a rule present in the previous manifest but
+	// absent from the current run; its policy file is deleted.
+	DiffStatusRemoved DiffStatus = "removed"
+)
+
+// DiffRule is one rule's classification within a DiffResult.
+type DiffRule struct {
+	GroupID    string     `json:"groupId"`
+	RuleID     string     `json:"ruleId"`
+	PolicyFile string     `json:"policyFile"`
+	Status     DiffStatus `json:"status"`
+}
+
+// DiffResult is the return value of STIGProcessor.ProcessDiff: every rule
+// classified against the previous run's manifest, plus the policy files
+// that were rewritten, left alone, or deleted as a result - so CI can
+// gate a PR review on only the files that actually changed.
+type DiffResult struct {
+	Rules          []DiffRule    `json:"rules"`
+	RewrittenFiles []string      `json:"rewrittenFiles"`
+	UnchangedFiles []string      `json:"unchangedFiles"`
+	DeletedFiles   []string      `json:"deletedFiles"`
+	Duration       time.Duration `json:"duration"`
 }
 
 // Validation structures
@@ -167,6 +553,18 @@ const (
 	ValidationErrorJSONSyntax  ValidationErrorType = "json_syntax"
 	ValidationErrorFleetSchema ValidationErrorType = "fleet_schema"
 	ValidationErrorSQLSyntax   ValidationErrorType = "sql_syntax"
+	// ValidationErrorDocumentSchema marks a pkg/document MDMD file that
+	// failed document.Validate (e.g. a fleet-policy document with no
+	// "satisfies" entries).
+	ValidationErrorDocumentSchema ValidationErrorType = "document_schema"
+	// ValidationErrorSARIFSchema and ValidationErrorOSCALSchema mark a
+	// -format sarif/oscal output file that failed its format's Validator
+	// (see processor.RegisterFormat).
+	ValidationErrorSARIFSchema ValidationErrorType = "sarif_schema"
+	ValidationErrorOSCALSchema ValidationErrorType = "oscal_schema"
+	// ValidationErrorRegoSyntax marks a -format rego output file missing
+	// even a bare "package" declaration.
+	ValidationErrorRegoSyntax ValidationErrorType = "rego_syntax"
 )
 
 // Severity levels enumeration
@@ -241,4 +639,16 @@ const (
 	DefaultTimeout        = 5 * time.Minute
 	DefaultMaxFileSize    = 100 * 1024 * 1024 // 100MB
 	DefaultMaxPolicyCount = 1000
+	// DefaultStreamWorkers is the generator worker count ProcessingOptions.StreamOutput
+	// uses when Workers is left at 0.
+	DefaultStreamWorkers = 4
+	// ManifestFilename is the name STIGProcessor.ProcessDiff uses for the
+	// per-rule fingerprint manifest it reads and rewrites in OutputDir.
+	ManifestFilename = ".stig-processor-manifest.json"
+	// BundleFleetGitOps is the ProcessingOptions.Bundle value selecting
+	// pkg/generator.BundleWriter's Fleet GitOps repo layout.
+	BundleFleetGitOps = "fleet-gitops"
+	// DefaultBundleTeam is the Fleet team a BundleFleetGitOps bundle is
+	// scoped to when ProcessingOptions.Team is empty.
+	DefaultBundleTeam = "default"
 )